@@ -1,6 +1,10 @@
 package common
 
-import "time"
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 const (
 	PulsesPerStep       int = 6
@@ -9,59 +13,311 @@ const (
 	tempoMin         float64 = 1.0
 	tempoMax         float64 = 300.0
 	updateBufferSize int     = 128
+
+	SwingMin int = 50
+	SwingMax int = 75
+
+	// Pulses fired later than lateThreshold are counted as late.
+	lateThreshold = time.Millisecond
+	// When the clock gets more than resyncThreshold pulses behind (ex: the
+	// process was suspended), it restarts its schedule from now instead of
+	// firing all the missed pulses at once.
+	resyncThreshold = PulsesPerStep
 )
 
-// clock manages the timing for MIDI playback, using a standard time.Ticker
-// to generate clock pulses. It provides functionality to update the tempo dynamically.
-// The update channel is used for receiving new tempo values and adjusting the ticker accordingly.
+// ClockStats holds the timing statistics of the clock since the last reset.
+// Lateness is the time elapsed between the target time of a pulse and the
+// time it was actually fired.
+type ClockStats struct {
+	Pulses  uint64        // Number of fired pulses.
+	Late    uint64        // Number of pulses fired later than lateThreshold.
+	Resyncs uint64        // Number of times the clock gave up catching up.
+	Dropped uint64        // Number of external pulses dropped while the clock was busy.
+	Last    time.Duration // Lateness of the last pulse.
+	Mean    time.Duration // Mean lateness.
+	Max     time.Duration // Max lateness.
+}
+
+// clock manages the timing for MIDI playback. Pulses are scheduled against
+// absolute target times computed from a monotonic origin, so that the time
+// spent in the tick callback and timer inaccuracies never add up to drift.
+// The lateness of each pulse is recorded in the clock stats.
+//
+// Tempo and swing changes are applied right away, then the clock goroutine
+// starts a new schedule segment at the pulse being waited for, scaling its
+// remaining time so that the phase stays continuous.
+//
+// Swing delays every other step: the first step of each pair of steps lasts
+// swing% of the pair, the second one the rest.
+//
+// The clock can also follow an external clock source: in that case, the
+// internal schedule is ignored and each external pulse triggers a tick. The
+// tempo is then estimated from the time elapsed between pulses.
 //
 // Read more: http://midi.teragonaudio.com/tech/midispec/clock.htm
 type Clock struct {
-	ticker       *time.Ticker
-	update       chan float64
-	tempo        float64
-	shouldUpdate bool // Flag to indicate if the ticker should be updated after the next tick.
+	mu     sync.Mutex
+	update chan struct{}
+	reset  chan struct{}
+	pulses chan time.Time
+	tempo  float64
+	swing  int
+	stats  ClockStats
+
+	scheduledTempo float64   // Tempo of the current schedule segment.
+	scheduledSwing int       // Swing of the current schedule segment.
+	pulse          uint64    // Number of ticks since the last reset, used for swing.
+	origin         uint64    // Pulse number of the current schedule segment origin.
+	originTime     time.Time // Target time of the origin pulse.
+	next           time.Time // Target time of the next pulse.
+
+	external    atomic.Bool // Flag to indicate if the clock follows external pulses.
+	pulseCount  int         // Number of external pulses received since quarterTime.
+	quarterTime time.Time   // Time of the first external pulse of the current quarter note.
 }
 
 // setTempo updates the tempo of the clock. It ensures the new tempo is within the defined range.
-// If the tempo is valid, it sends the new tempo to the update channel.
+// If the tempo is valid, the clock goroutine is notified to update its schedule.
 func (c *Clock) SetTempo(tempo float64) {
 	if tempo > tempoMax || tempo < tempoMin {
 		return
 	}
-	c.update <- tempo
+	c.mu.Lock()
+	c.tempo = tempo
+	c.mu.Unlock()
+	c.notify()
 }
 
 // Tempo returns the tempo of the clock.
 func (c *Clock) Tempo() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.tempo
 }
 
+// SetSwing updates the swing amount of the clock, in percent.
+func (c *Clock) SetSwing(swing int) {
+	if swing > SwingMax || swing < SwingMin {
+		return
+	}
+	c.mu.Lock()
+	c.swing = swing
+	c.mu.Unlock()
+	c.notify()
+}
+
+// notify notifies the clock goroutine that the tempo or the swing changed.
+func (c *Clock) notify() {
+	select {
+	case c.update <- struct{}{}:
+	default:
+	}
+}
+
+// Swing returns the swing amount of the clock, in percent.
+func (c *Clock) Swing() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.swing
+}
+
+// Stats returns the timing statistics of the clock since the last reset.
+func (c *Clock) Stats() ClockStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Reset resets the clock pulse counter so that swing is applied on the
+// right steps when playback starts. The clock stats are reset as well.
+func (c *Clock) Reset() {
+	select {
+	case c.reset <- struct{}{}:
+	default:
+	}
+}
+
+// External returns true if the clock follows an external clock source.
+func (c *Clock) External() bool {
+	return c.external.Load()
+}
+
+// SetExternal enables or disables the external clock mode.
+func (c *Clock) SetExternal(external bool) {
+	c.external.Store(external)
+}
+
+// Pulse sends an external pulse to the clock. It's ignored if the clock
+// does not follow an external clock source. It never blocks the caller
+// (the midi input listener): pulses received while the clock is too busy
+// to buffer them are dropped and counted in the clock stats.
+func (c *Clock) Pulse() {
+	if !c.External() {
+		return
+	}
+	select {
+	case c.pulses <- time.Now():
+	default:
+		c.mu.Lock()
+		c.stats.Dropped++
+		c.mu.Unlock()
+	}
+}
+
 // NewClock creates and initializes a new clock instance with the specified tempo
 // and a callback function that is called on each tick. It starts a goroutine to
 // manage the clock ticks and tempo updates.
 func NewClock(tempo float64, tick func()) *Clock {
-	c := &Clock{
-		ticker: time.NewTicker(newClockInterval(tempo)),
-		update: make(chan float64, updateBufferSize),
-		tempo:  tempo,
+	c := NewManualClock(tempo)
+	go c.run(tick)
+	return c
+}
+
+// NewManualClock creates a clock that never ticks by itself: it only holds
+// the tempo and the swing, its owner pulsing at its own pace (ex: when
+// rendering offline).
+func NewManualClock(tempo float64) *Clock {
+	now := time.Now()
+	return &Clock{
+		update:         make(chan struct{}, 1),
+		reset:          make(chan struct{}, 1),
+		pulses:         make(chan time.Time, updateBufferSize),
+		tempo:          tempo,
+		swing:          SwingMin,
+		scheduledTempo: tempo,
+		scheduledSwing: SwingMin,
+		originTime:     now,
+		next:           now,
 	}
-	go func(c *Clock) {
-		for {
-			select {
-			case <-c.ticker.C:
+}
+
+// run fires the clock ticks, following either the internal schedule or the
+// external pulses, and applies the tempo changes and resets.
+func (c *Clock) run(tick func()) {
+	timer := time.NewTimer(time.Until(c.next))
+	for {
+		select {
+		case <-timer.C:
+			if !c.External() {
+				c.record(time.Since(c.next))
 				tick()
-				if c.shouldUpdate {
-					c.ticker.Reset(newClockInterval(c.tempo))
-					c.shouldUpdate = false
-				}
-			case newTempo := <-c.update:
-				c.shouldUpdate = true
-				c.tempo = newTempo
 			}
+			c.schedule()
+			timer.Reset(time.Until(c.next))
+		case t := <-c.pulses:
+			c.estimateTempo(t)
+			tick()
+		case <-c.update:
+			c.reschedule()
+			timer.Reset(time.Until(c.next))
+		case <-c.reset:
+			c.pulse = 0
+			c.origin = 0
+			c.originTime = c.next
+			c.mu.Lock()
+			c.stats = ClockStats{}
+			c.mu.Unlock()
 		}
-	}(c)
-	return c
+	}
+}
+
+// schedule moves to the next pulse and computes its target time from the
+// origin of the current schedule segment.
+func (c *Clock) schedule() {
+	c.pulse++
+	c.next = c.originTime.Add(time.Duration(c.position(c.pulse) - c.position(c.origin)))
+	if time.Since(c.next) <= time.Duration(resyncThreshold)*c.pulseDuration() {
+		return
+	}
+	c.origin = c.pulse
+	c.originTime = time.Now()
+	c.next = c.originTime
+	c.mu.Lock()
+	c.stats.Resyncs++
+	c.mu.Unlock()
+}
+
+// reschedule starts a new schedule segment at the pulse being waited for,
+// after a tempo or swing change. Its remaining time is scaled from the
+// previous pulse duration to the new one.
+func (c *Clock) reschedule() {
+	previous := c.pulseDuration()
+	c.mu.Lock()
+	c.scheduledTempo, c.scheduledSwing = c.tempo, c.swing
+	c.mu.Unlock()
+
+	now := time.Now()
+	remaining := max(c.next.Sub(now), 0)
+	if previous > 0 {
+		remaining = time.Duration(float64(remaining) * float64(c.pulseDuration()) / float64(previous))
+	}
+	c.origin = c.pulse
+	c.originTime = now.Add(remaining)
+	c.next = c.originTime
+}
+
+// record updates the clock stats with the lateness of a fired pulse.
+func (c *Clock) record(lateness time.Duration) {
+	lateness = max(lateness, 0)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Pulses++
+	c.stats.Last = lateness
+	c.stats.Max = max(c.stats.Max, lateness)
+	c.stats.Mean += (lateness - c.stats.Mean) / time.Duration(c.stats.Pulses)
+	if lateness > lateThreshold {
+		c.stats.Late++
+	}
+}
+
+// pulseDuration returns the duration of the pulse being waited for.
+func (c *Clock) pulseDuration() time.Duration {
+	if c.pulse == 0 {
+		return time.Duration(c.position(1))
+	}
+	return time.Duration(c.position(c.pulse) - c.position(c.pulse-1))
+}
+
+// position returns the time of a pulse relative to pulse 0, in nanoseconds,
+// for the current schedule segment tempo and swing.
+func (c *Clock) position(pulse uint64) float64 {
+	return SwingPosition(pulse, c.scheduledSwing) * float64(time.Minute) / (c.scheduledTempo * float64(PulsesPerStep*StepsPerQuarterNote))
+}
+
+// SwingPosition returns the position of a pulse relative to pulse 0, in
+// pulses, for the given swing amount. The first step of each pair of steps
+// is lengthened and the second one shortened according to the swing amount.
+func SwingPosition(pulse uint64, swing int) float64 {
+	step := uint64(PulsesPerStep)
+	pairs, rem := pulse/(2*step), pulse%(2*step)
+	position := float64(pairs * 2 * step)
+	long := float64(swing) / 50
+	if rem <= step {
+		return position + float64(rem)*long
+	}
+	short := float64(100-swing) / 50
+	return position + float64(step)*long + float64(rem-step)*short
+}
+
+// estimateTempo computes the tempo from the external pulses, once per
+// quarter note, to smooth out the jitter of incoming midi messages.
+func (c *Clock) estimateTempo(t time.Time) {
+	pulsesPerQuarterNote := PulsesPerStep * StepsPerQuarterNote
+	if c.pulseCount == 0 || t.Sub(c.quarterTime) > time.Duration(pulsesPerQuarterNote)*newClockInterval(tempoMin) {
+		c.pulseCount = 0
+		c.quarterTime = t
+	}
+	c.pulseCount++
+	if c.pulseCount <= pulsesPerQuarterNote {
+		return
+	}
+	tempo := float64(time.Minute) / float64(t.Sub(c.quarterTime))
+	c.mu.Lock()
+	c.tempo = min(max(tempo, tempoMin), tempoMax)
+	c.mu.Unlock()
+	c.notify()
+	c.pulseCount = 1
+	c.quarterTime = t
 }
 
 // newClockInterval calculates the duration of each tick based on the current tempo.
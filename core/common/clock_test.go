@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockPosition(t *testing.T) {
+	c := &Clock{scheduledTempo: 120, scheduledSwing: 60}
+	step := float64(time.Minute) / (120 * float64(StepsPerQuarterNote))
+
+	tests := []struct {
+		pulse uint64
+		want  float64
+	}{
+		{0, 0},
+		{uint64(PulsesPerStep), step * 1.2},
+		{uint64(2 * PulsesPerStep), step * 2},
+		{uint64(3 * PulsesPerStep), step * 3.2},
+		{uint64(96 * PulsesPerStep), step * 96},
+	}
+	for _, tt := range tests {
+		if got := c.position(tt.pulse); got-tt.want > 1 || tt.want-got > 1 {
+			t.Errorf("position(%d) = %f, want %f", tt.pulse, got, tt.want)
+		}
+	}
+}
+
+// TestClockPulseDropped checks that external pulses never block when the
+// clock is busy, the dropped ones being counted.
+func TestClockPulseDropped(t *testing.T) {
+	c := NewManualClock(120)
+	c.SetExternal(true)
+	for range updateBufferSize + 2 {
+		c.Pulse()
+	}
+	if got := c.Stats().Dropped; got != 2 {
+		t.Errorf("dropped %d pulses, want 2", got)
+	}
+}
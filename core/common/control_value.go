@@ -1,10 +1,6 @@
 package common
 
-import (
-	"math"
-	"math/rand"
-	"time"
-)
+import "slices"
 
 type Number interface {
 	int | uint8 | int16
@@ -15,17 +11,18 @@ type ControlValue[T Number] struct {
 	last     T
 	min, max T
 	amount   int
-	rand     *rand.Rand
+	sequence Sequence[T]
+
+	distribution Distribution
+	walk         int // Offset of the last value, drunk walks step from it.
 }
 
 func NewControlValue[T Number](value T, min T, max T) *ControlValue[T] {
-	source := rand.NewSource(time.Now().UnixNano())
 	return &ControlValue[T]{
 		val:  value,
 		last: value,
 		min:  min,
 		max:  max,
-		rand: rand.New(source),
 	}
 }
 
@@ -33,18 +30,20 @@ func (p *ControlValue[T]) Value() T {
 	return p.val
 }
 
-func (p *ControlValue[T]) Computed() T {
+// Computed returns a new value from the sequence or the base value, offset
+// by a random amount drawn from the given random source.
+func (p *ControlValue[T]) Computed(rand *Random) T {
+	base := p.val
+	if p.sequence.Len() > 0 {
+		base = p.sequence.Next(rand)
+	}
 	if p.amount == 0 {
-		p.last = p.val
+		p.last = base
 		return p.last
 	}
-	value := T(p.rand.Intn(int(math.Abs(float64(p.amount))) + 1))
-	if p.amount > 0 {
-		value = p.val + value
-	} else {
-		value = p.val - value
-	}
-	p.last = max(min(value, p.max), p.min)
+	value := int(base) + p.distribution.Offset(rand, p.amount, p.walk)
+	p.last = T(max(min(value, int(p.max)), int(p.min)))
+	p.walk = int(p.last) - int(base)
 	return p.last
 }
 
@@ -77,6 +76,42 @@ func (p *ControlValue[T]) SetRandomAmount(amount int) {
 	p.amount = amount
 }
 
+// Distribution returns the distribution of the random amount.
+func (p *ControlValue[T]) Distribution() Distribution {
+	return p.distribution
+}
+
+// SetDistribution changes the distribution of the random amount.
+func (p *ControlValue[T]) SetDistribution(distribution Distribution) {
+	if distribution < UNIFORM || distribution > MaxDistribution {
+		return
+	}
+	p.distribution = distribution
+	p.walk = 0
+}
+
+// Sequence returns the sequence of values used instead of the base value
+// on each computation, empty when not in sequence mode.
+func (p *ControlValue[T]) Sequence() *Sequence[T] {
+	return &p.sequence
+}
+
+// SetSequence sets the sequence of values used on each computation, values
+// out of range are ignored. An empty sequence leaves the sequence mode.
+func (p *ControlValue[T]) SetSequence(values []T, mode SequenceMode) {
+	p.sequence.Set(slices.DeleteFunc(slices.Clone(values), func(v T) bool {
+		return v < p.min || v > p.max
+	}), mode)
+}
+
+// Rewind restarts the sequence and the random walk, and resets the last
+// value to the base value, so that computations start over as on load.
+func (p *ControlValue[T]) Rewind() {
+	p.sequence.Rewind()
+	p.walk = 0
+	p.last = p.val
+}
+
 func (p *ControlValue[T]) Min() T {
 	return p.min
 }
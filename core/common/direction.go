@@ -8,18 +8,43 @@ type Direction int
 // Constants representing individual directions as bit flags.
 // Each direction is assigned a distinct bit position, allowing multiple directions
 // to be combined using bitwise operations.
+// Diagonals come after the four straight directions so that directions saved
+// before diagonals existed keep their meaning.
 const (
 	NONE Direction = 0
 	UP   Direction = 1 << iota
 	RIGHT
 	DOWN
 	LEFT
+	UPRIGHT
+	DOWNRIGHT
+	DOWNLEFT
+	UPLEFT
+
+	straightDirections = UP | RIGHT | DOWN | LEFT
+	diagonalDirections = UPRIGHT | DOWNRIGHT | DOWNLEFT | UPLEFT
 )
 
 // Predefined variables for managing directions and their string representations.
 var (
-	// allDirections is a slice containing all the basic directional constants.
-	allDirections = []Direction{UP, RIGHT, DOWN, LEFT}
+	// allDirections is a slice containing all the basic directional constants,
+	// in clockwise order.
+	allDirections = []Direction{UP, UPRIGHT, RIGHT, DOWNRIGHT, DOWN, DOWNLEFT, LEFT, UPLEFT}
+
+	// diagonalSymbols maps diagonal combinations to their corresponding string
+	// symbols.
+	diagonalSymbols = map[Direction]string{
+		UPRIGHT:              "↗",
+		DOWNRIGHT:            "↘",
+		DOWNLEFT:             "↙",
+		UPLEFT:               "↖",
+		UPRIGHT | DOWNLEFT:   "╱",
+		UPLEFT | DOWNRIGHT:   "╲",
+		UPRIGHT | UPLEFT:     "^",
+		DOWNRIGHT | DOWNLEFT: "v",
+		UPRIGHT | DOWNRIGHT:  ">",
+		UPLEFT | DOWNLEFT:    "<",
+	}
 
 	// symbols maps direction combinations to their corresponding string symbols.
 	symbols = map[Direction]string{
@@ -73,11 +98,64 @@ func (d Direction) NextPosition(x, y int) (int, int) {
 		return x, y + 1
 	case LEFT:
 		return x - 1, y
+	case UPRIGHT:
+		return x + 1, y - 1
+	case DOWNRIGHT:
+		return x + 1, y + 1
+	case DOWNLEFT:
+		return x - 1, y + 1
+	case UPLEFT:
+		return x - 1, y - 1
 	default:
 		return 0, 0 // Default case, should not be reached if directions are properly handled.
 	}
 }
 
+// RotateClockwise rotates each basic direction a quarter turn clockwise.
+func (d Direction) RotateClockwise() Direction {
+	return d.rotate(2)
+}
+
+// RotateCounterClockwise rotates each basic direction a quarter turn
+// counterclockwise.
+func (d Direction) RotateCounterClockwise() Direction {
+	return d.rotate(len(allDirections) - 2)
+}
+
+// rotate rotates each basic direction clockwise by the given number of
+// eighths of a turn.
+func (d Direction) rotate(eighths int) Direction {
+	rotated := NONE
+	for i, dir := range allDirections {
+		if d.Contains(dir) {
+			rotated = rotated.Add(allDirections[(i+eighths)%len(allDirections)])
+		}
+	}
+	return rotated
+}
+
+// FlipHorizontal swaps the left and right components of the direction.
+func (d Direction) FlipHorizontal() Direction {
+	return d.swap(LEFT, RIGHT).swap(UPLEFT, UPRIGHT).swap(DOWNLEFT, DOWNRIGHT)
+}
+
+// FlipVertical swaps the up and down components of the direction.
+func (d Direction) FlipVertical() Direction {
+	return d.swap(UP, DOWN).swap(UPLEFT, DOWNLEFT).swap(UPRIGHT, DOWNRIGHT)
+}
+
+// swap swaps two basic directions.
+func (d Direction) swap(a, b Direction) Direction {
+	swapped := d.Remove(a | b)
+	if d.Contains(a) {
+		swapped = swapped.Add(b)
+	}
+	if d.Contains(b) {
+		swapped = swapped.Add(a)
+	}
+	return swapped
+}
+
 // Add combines the current direction with another direction.
 func (d Direction) Add(dir Direction) Direction {
 	return d | dir
@@ -94,7 +172,17 @@ func (d Direction) Contains(dir Direction) bool {
 }
 
 // Symbol returns the string symbol associated with the current direction.
+// Directions mixing straight and diagonal directions, or with too many
+// diagonals to be drawn, share the same symbol.
 func (d Direction) Symbol() string {
+	if d.Contains(straightDirections) && d.Contains(diagonalDirections) {
+		return "*"
+	} else if d.Contains(diagonalDirections) {
+		if s, ok := diagonalSymbols[d]; ok {
+			return s
+		}
+		return "╳"
+	}
 	if s, ok := symbols[d]; ok {
 		return s
 	}
@@ -0,0 +1,45 @@
+package common
+
+import "testing"
+
+func TestDirectionBits(t *testing.T) {
+	// Straight directions are saved in bank files and must keep their values.
+	for dir, want := range map[Direction]int{UP: 2, RIGHT: 4, DOWN: 8, LEFT: 16} {
+		if int(dir) != want {
+			t.Errorf("direction %s is %d, want %d", dir.Symbol(), dir, want)
+		}
+	}
+}
+
+func TestDirectionDiagonals(t *testing.T) {
+	tests := []struct {
+		dir            Direction
+		x, y           int
+		clockwise      Direction
+		flipHorizontal Direction
+		flipVertical   Direction
+	}{
+		{UPRIGHT, 1, -1, DOWNRIGHT, UPLEFT, DOWNRIGHT},
+		{DOWNRIGHT, 1, 1, DOWNLEFT, DOWNLEFT, UPRIGHT},
+		{DOWNLEFT, -1, 1, UPLEFT, DOWNRIGHT, UPLEFT},
+		{UPLEFT, -1, -1, UPRIGHT, UPRIGHT, DOWNLEFT},
+	}
+	for _, tt := range tests {
+		if x, y := tt.dir.NextPosition(0, 0); x != tt.x || y != tt.y {
+			t.Errorf("%s next position is %d,%d, want %d,%d", tt.dir.Symbol(), x, y, tt.x, tt.y)
+		}
+		if got := tt.dir.RotateClockwise(); got != tt.clockwise {
+			t.Errorf("%s rotated clockwise is %s, want %s", tt.dir.Symbol(), got.Symbol(), tt.clockwise.Symbol())
+		}
+		if got := tt.dir.FlipHorizontal(); got != tt.flipHorizontal {
+			t.Errorf("%s flipped horizontally is %s, want %s", tt.dir.Symbol(), got.Symbol(), tt.flipHorizontal.Symbol())
+		}
+		if got := tt.dir.FlipVertical(); got != tt.flipVertical {
+			t.Errorf("%s flipped vertically is %s, want %s", tt.dir.Symbol(), got.Symbol(), tt.flipVertical.Symbol())
+		}
+	}
+
+	if got := (UP | UPRIGHT | LEFT).Decompose(); len(got) != 3 || got[0] != UP || got[1] != UPRIGHT || got[2] != LEFT {
+		t.Errorf("unexpected decomposition %v", got)
+	}
+}
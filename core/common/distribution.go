@@ -0,0 +1,71 @@
+package common
+
+import "math"
+
+// Distribution constants represent the ways random amounts are drawn.
+const (
+	UNIFORM Distribution = iota
+	BIPOLAR
+	GAUSSIAN
+	DRUNK
+	WEIGHTED
+
+	MaxDistribution = WEIGHTED
+)
+
+var distributionNames = map[Distribution]string{
+	UNIFORM:  "uniform",
+	BIPOLAR:  "bipolar",
+	GAUSSIAN: "gaussian",
+	DRUNK:    "drunk",
+	WEIGHTED: "weighted",
+}
+
+// Distribution represents how random offsets are spread around a value.
+type Distribution int
+
+// Name returns the name of the distribution.
+func (d Distribution) Name() string {
+	return distributionNames[d]
+}
+
+// Offset returns a random offset to apply to a value, up to the amount.
+// Uniform and weighted offsets follow the sign of the amount, others go
+// both ways. Drunk walks step from the previous offset.
+func (d Distribution) Offset(rand *Random, amount int, previous int) int {
+	spread := int(math.Abs(float64(amount)))
+	offset := 0
+	switch d {
+	case BIPOLAR:
+		return rand.Intn(2*spread+1) - spread
+	case GAUSSIAN:
+		// The amount is twice the standard deviation, farther draws are
+		// clamped to it.
+		offset = int(math.Round(rand.NormFloat64() * float64(spread) / 2))
+		return max(min(offset, spread), -spread)
+	case DRUNK:
+		return previous + rand.Intn(2*spread+1) - spread
+	case WEIGHTED:
+		offset = weightedOffset(rand, spread)
+	default:
+		offset = rand.Intn(spread + 1)
+	}
+	if amount < 0 {
+		return -offset
+	}
+	return offset
+}
+
+// weightedOffset picks an offset from 0 to spread, each offset weighing one
+// more than the next farther one: with a spread of 3, offsets 0 to 3 weigh
+// 4, 3, 2 and 1.
+func weightedOffset(rand *Random, spread int) int {
+	draw := rand.Intn((spread + 1) * (spread + 2) / 2)
+	for offset := 0; ; offset++ {
+		weight := spread + 1 - offset
+		if draw < weight {
+			return offset
+		}
+		draw -= weight
+	}
+}
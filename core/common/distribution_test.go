@@ -0,0 +1,60 @@
+package common
+
+import "testing"
+
+func TestDistributionOffset(t *testing.T) {
+	tests := []struct {
+		distribution Distribution
+		amount       int
+		previous     int
+		min, max     int
+	}{
+		{UNIFORM, 5, 0, 0, 5},
+		{UNIFORM, -5, 0, -5, 0},
+		{BIPOLAR, 5, 0, -5, 5},
+		{BIPOLAR, -5, 0, -5, 5},
+		{GAUSSIAN, 5, 0, -5, 5},
+		{DRUNK, 2, 10, 8, 12},
+		{WEIGHTED, 5, 0, 0, 5},
+		{WEIGHTED, -5, 0, -5, 0},
+	}
+	rand := NewRandom(1)
+	for _, tt := range tests {
+		for range 100 {
+			if got := tt.distribution.Offset(rand, tt.amount, tt.previous); got < tt.min || got > tt.max {
+				t.Errorf("%s offset of %d is %d, want between %d and %d", tt.distribution.Name(), tt.amount, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestWeightedOffset(t *testing.T) {
+	const draws = 10000
+	counts := make([]int, 4)
+	rand := NewRandom(1)
+	for range draws {
+		counts[weightedOffset(rand, 3)]++
+	}
+	// Offsets 0 to 3 weigh 4, 3, 2 and 1 out of 10.
+	for offset, count := range counts {
+		want := draws * (4 - offset) / 10
+		if count < want*9/10 || count > want*11/10 {
+			t.Errorf("offset %d drawn %d times, want about %d", offset, count, want)
+		}
+	}
+}
+
+func TestControlValueDrunk(t *testing.T) {
+	v := NewControlValue[uint8](100, 0, 127)
+	v.SetRandomAmount(3)
+	v.SetDistribution(DRUNK)
+	rand := NewRandom(1)
+	last := int(v.Value())
+	for range 200 {
+		got := int(v.Computed(rand))
+		if got < last-3 || got > last+3 {
+			t.Fatalf("drunk value stepped from %d to %d, want at most 3", last, got)
+		}
+		last = got
+	}
+}
@@ -56,6 +56,54 @@ type EmitterBehavior interface {
 type Movable interface {
 	// MustMove checks if the node must move during the current pulse.
 	MustMove(pulse uint64) bool
+
+	// Expired checks if the node reached the end of its lifetime.
+	Expired() bool
+
+	// Cells returns the number of cells the node moves by at once.
+	Cells() int
+}
+
+// Rated represents an interface for nodes running at their own rate.
+type Rated interface {
+	Rate() Rate
+	SetRate(rate Rate)
+}
+
+// Signaling represents an interface for nodes emitting signals with their
+// own speed and lifetime.
+type Signaling interface {
+	Speed() Speed
+	SetSpeed(speed Speed)
+	Lifetime() int
+	SetLifetime(lifetime int)
+}
+
+// Router represents an interface for nodes redirecting signals without
+// triggering anything.
+type Router interface {
+	// Route returns the direction of a signal leaving the node after
+	// entering it in the given direction, NONE when the signal is blocked.
+	Route(dir Direction) Direction
+}
+
+// Carrier represents an interface for nodes carrying a payload along with
+// their signals.
+type Carrier interface {
+	// Payload returns the payload carried by the node, or sent with its
+	// next signals for emitters.
+	Payload() Payload
+
+	// SetPayload sets the transposition and velocity change of the payload.
+	SetPayload(payload Payload)
+}
+
+// Receiver represents an interface for nodes keeping the payload of the
+// signal that last triggered them.
+type Receiver interface {
+	// Received returns the payload of the last signal that triggered the
+	// node, an empty payload until it is triggered by a signal.
+	Received() Payload
 }
 
 // Repeatable represents an interface for nodes that can repeat directions.
@@ -85,7 +133,7 @@ type Copyable interface {
 // updated from the ui.
 type Parameter[T any] interface {
 	Value() T
-	Computed() T
+	Computed(rand *Random) T
 	Last() T
 	Set(value T)
 	RandomAmount() int
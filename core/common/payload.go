@@ -0,0 +1,40 @@
+package common
+
+import "fmt"
+
+// Constants defining the limits of the payload values.
+const (
+	MaxPayloadTranspose = 48
+	MaxPayloadVelocity  = 100
+)
+
+// Payload is the data carried by signals from their emitter to the nodes
+// they trigger.
+type Payload struct {
+	Transpose int  // Semitones added to the triggered notes.
+	Velocity  int  // Velocity change of the triggered notes, in percent.
+	Origin    Node // Node that emitted the signal, nil for direct triggers.
+	Hops      int  // Number of emitters the signal went through, including its origin.
+}
+
+// ScaleVelocity applies the payload velocity change to a midi velocity. A
+// scaled velocity never gets to 0, which would stop the note.
+func (p Payload) ScaleVelocity(velocity uint8) uint8 {
+	if p.Velocity == 0 || velocity == 0 {
+		return velocity
+	}
+	scaled := int(velocity) * (100 + p.Velocity) / 100
+	return uint8(min(max(scaled, 1), 127))
+}
+
+// Describe returns a short description of where the payload comes from, an
+// empty string for direct triggers.
+func (p Payload) Describe() string {
+	if p.Origin == nil {
+		return ""
+	}
+	if p.Hops == 1 {
+		return fmt.Sprintf("from %s", p.Origin.Name())
+	}
+	return fmt.Sprintf("from %s, %d hops", p.Origin.Name(), p.Hops)
+}
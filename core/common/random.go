@@ -0,0 +1,68 @@
+package common
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MaxSeed is the highest seed value, kept short so that seeds can easily
+// be noted down and typed back.
+const MaxSeed int64 = 99999
+
+// Random is a seedable random source, safe for concurrent use.
+type Random struct {
+	mu   sync.Mutex
+	seed int64
+	rand *rand.Rand
+}
+
+// NewRandom creates a random source from a seed.
+func NewRandom(seed int64) *Random {
+	return &Random{
+		seed: seed,
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewSeed returns a new seed based on the current time.
+func NewSeed() int64 {
+	return time.Now().UnixNano()%MaxSeed + 1
+}
+
+// Seed returns the seed of the random source.
+func (r *Random) Seed() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seed
+}
+
+// SetSeed changes the seed of the random source and restarts its sequence.
+func (r *Random) SetSeed(seed int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seed = seed
+	r.rand.Seed(seed)
+}
+
+// Reset restarts the random sequence from the seed.
+func (r *Random) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rand.Seed(r.seed)
+}
+
+// Intn returns a non-negative pseudo-random number in [0,n).
+func (r *Random) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Intn(n)
+}
+
+// NormFloat64 returns a normally distributed float64 with a mean of 0 and a
+// standard deviation of 1.
+func (r *Random) NormFloat64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.NormFloat64()
+}
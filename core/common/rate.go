@@ -0,0 +1,63 @@
+package common
+
+// Rate is a custom type representing the speed of a node relative to the
+// grid steps. The normal rate runs a step every PulsesPerStep pulses.
+type Rate int
+
+// Constants representing the available rates, from the slowest to the
+// fastest one. The normal rate is the zero value so that nodes saved
+// without any rate run at the grid speed.
+const (
+	QUARTER Rate = iota - 2
+	HALF
+	NORMAL
+	TRIPLET
+	DOUBLE
+	TRIPLE
+
+	MinRate = QUARTER
+	MaxRate = TRIPLE
+)
+
+var (
+	// ratePulses maps rates to the number of pulses between two steps.
+	ratePulses = map[Rate]int{
+		QUARTER: 4 * PulsesPerStep,
+		HALF:    2 * PulsesPerStep,
+		NORMAL:  PulsesPerStep,
+		TRIPLET: 2 * PulsesPerStep / 3,
+		DOUBLE:  PulsesPerStep / 2,
+		TRIPLE:  PulsesPerStep / 3,
+	}
+
+	// rateSymbols maps rates to their corresponding string symbols.
+	rateSymbols = map[Rate]string{
+		QUARTER: "1/4",
+		HALF:    "1/2",
+		NORMAL:  "x1",
+		TRIPLET: "3:2",
+		DOUBLE:  "x2",
+		TRIPLE:  "x3",
+	}
+)
+
+// Pulses returns the number of pulses between two steps at this rate.
+func (r Rate) Pulses() int {
+	if pulses, ok := ratePulses[r]; ok {
+		return pulses
+	}
+	return PulsesPerStep
+}
+
+// IsStep returns true if the given pulse is a step at this rate.
+func (r Rate) IsStep(pulse uint64) bool {
+	return pulse%uint64(r.Pulses()) == 0
+}
+
+// Symbol returns the string representation of the rate.
+func (r Rate) Symbol() string {
+	if symbol, ok := rateSymbols[r]; ok {
+		return symbol
+	}
+	return rateSymbols[NORMAL]
+}
@@ -0,0 +1,132 @@
+package common
+
+import "slices"
+
+// SequenceMode constants represent the ways a sequence advances on each
+// computation.
+const (
+	FORWARD SequenceMode = iota
+	BACKWARD
+	PINGPONG
+	RANDOM
+)
+
+// MaxSequenceLength is the maximum number of values in a sequence.
+const MaxSequenceLength = 32
+
+var (
+	sequenceModeNames = map[SequenceMode]string{
+		FORWARD:  "forward",
+		BACKWARD: "backward",
+		PINGPONG: "ping-pong",
+		RANDOM:   "random",
+	}
+
+	sequenceModeSymbols = map[SequenceMode]string{
+		FORWARD:  ">",
+		BACKWARD: "<",
+		PINGPONG: "<>",
+		RANDOM:   "?",
+	}
+)
+
+// SequenceMode represents the order in which sequence values are used.
+type SequenceMode int
+
+// Name returns the name of the sequence mode.
+func (m SequenceMode) Name() string {
+	return sequenceModeNames[m]
+}
+
+// Symbol returns the symbol of the sequence mode.
+func (m SequenceMode) Symbol() string {
+	return sequenceModeSymbols[m]
+}
+
+// ParseSequenceMode returns the sequence mode matching a symbol.
+func ParseSequenceMode(symbol string) (SequenceMode, bool) {
+	for mode, s := range sequenceModeSymbols {
+		if s == symbol {
+			return mode, true
+		}
+	}
+	return FORWARD, false
+}
+
+// Sequence is a list of values cycled through, one value on each call to
+// Next.
+type Sequence[T Number] struct {
+	values   []T
+	mode     SequenceMode
+	step     int
+	backward bool // Current direction of ping-pong sequences.
+}
+
+// Values returns the values of the sequence.
+func (s *Sequence[T]) Values() []T {
+	return s.values
+}
+
+// Mode returns the sequence mode.
+func (s *Sequence[T]) Mode() SequenceMode {
+	return s.mode
+}
+
+// Len returns the number of values in the sequence, 0 when there's no
+// sequence.
+func (s *Sequence[T]) Len() int {
+	return len(s.values)
+}
+
+// Set replaces the sequence values and mode, and rewinds it. Extra values
+// are dropped.
+func (s *Sequence[T]) Set(values []T, mode SequenceMode) {
+	s.values = slices.Clone(values[:min(len(values), MaxSequenceLength)])
+	s.mode = mode
+	s.Rewind()
+}
+
+// Clear removes all values of the sequence.
+func (s *Sequence[T]) Clear() {
+	s.Set(nil, FORWARD)
+}
+
+// Rewind restarts the sequence from its first value.
+func (s *Sequence[T]) Rewind() {
+	s.step = 0
+	s.backward = false
+	if s.mode == BACKWARD && len(s.values) > 0 {
+		s.step = len(s.values) - 1
+	}
+}
+
+// Next returns the current value of the sequence and advances it. Random
+// sequences draw their values from the given random source.
+func (s *Sequence[T]) Next(rand *Random) T {
+	n := len(s.values)
+	if s.mode == RANDOM {
+		return s.values[rand.Intn(n)]
+	}
+	value := s.values[s.step]
+	switch s.mode {
+	case FORWARD:
+		s.step = (s.step + 1) % n
+	case BACKWARD:
+		s.step = (s.step - 1 + n) % n
+	case PINGPONG:
+		if n == 1 {
+			break
+		}
+		if s.step == n-1 {
+			s.backward = true
+		} else if s.step == 0 {
+			s.backward = false
+		}
+		if s.backward {
+			s.step--
+		} else {
+			s.step++
+		}
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		mode   SequenceMode
+		values []int
+		want   []int
+	}{
+		{FORWARD, []int{1, 2, 3}, []int{1, 2, 3, 1, 2, 3, 1}},
+		{BACKWARD, []int{1, 2, 3}, []int{3, 2, 1, 3, 2, 1, 3}},
+		{PINGPONG, []int{1, 2, 3}, []int{1, 2, 3, 2, 1, 2, 3}},
+		{PINGPONG, []int{1}, []int{1, 1, 1}},
+	}
+	rand := NewRandom(1)
+	for _, tt := range tests {
+		var s Sequence[int]
+		s.Set(tt.values, tt.mode)
+		got := make([]int, len(tt.want))
+		for i := range got {
+			got[i] = s.Next(rand)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s sequence is %v, want %v", tt.mode.Name(), got, tt.want)
+		}
+		s.Rewind()
+		if v := s.Next(rand); v != tt.want[0] {
+			t.Errorf("rewound %s sequence starts at %d, want %d", tt.mode.Name(), v, tt.want[0])
+		}
+	}
+}
+
+func TestSequenceRandom(t *testing.T) {
+	rand := NewRandom(1)
+	var s Sequence[int]
+	s.Set([]int{1, 2, 3}, RANDOM)
+	for range 20 {
+		if v := s.Next(rand); !slices.Contains(s.Values(), v) {
+			t.Errorf("random sequence returned %d, not in %v", v, s.Values())
+		}
+	}
+}
+
+func TestControlValueSequence(t *testing.T) {
+	v := NewControlValue[uint8](100, 0, 127)
+	v.SetSequence([]uint8{100, 60, 200, 80}, FORWARD)
+	rand := NewRandom(1)
+	got := []uint8{v.Computed(rand), v.Computed(rand), v.Computed(rand), v.Computed(rand)}
+	// Values out of range are dropped.
+	if want := []uint8{100, 60, 80, 100}; !slices.Equal(got, want) {
+		t.Errorf("computed values %v, want %v", got, want)
+	}
+}
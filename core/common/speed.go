@@ -0,0 +1,42 @@
+package common
+
+import "fmt"
+
+// Speed is a custom type representing how fast signals move across the
+// grid. Positive speeds move signals by several cells per step, negative
+// speeds move them once every few steps. The zero value moves signals by
+// one cell per step so that nodes saved without any speed keep moving at
+// the usual pace.
+type Speed int
+
+const (
+	MinSpeed Speed = -7
+	MaxSpeed Speed = 7
+
+	// MaxLifetime is the maximum lifetime of a signal, in steps.
+	MaxLifetime = 128
+)
+
+// Cells returns the number of cells a signal moves by at once.
+func (s Speed) Cells() int {
+	if s < 0 {
+		return 1
+	}
+	return int(s) + 1
+}
+
+// Steps returns the number of steps between two signal moves.
+func (s Speed) Steps() int {
+	if s > 0 {
+		return 1
+	}
+	return 1 - int(s)
+}
+
+// Symbol returns the string representation of the speed.
+func (s Speed) Symbol() string {
+	if s < 0 {
+		return fmt.Sprintf("1/%d", s.Steps())
+	}
+	return fmt.Sprintf("%d", s.Cells())
+}
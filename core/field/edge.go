@@ -0,0 +1,56 @@
+package field
+
+import "signls/core/common"
+
+// EdgeMode is the behavior of signals reaching the grid edges.
+type EdgeMode int
+
+// Constants representing the available edge modes.
+const (
+	KILL   EdgeMode = iota // Signals disappear.
+	WRAP                   // Signals reappear on the opposite edge.
+	BOUNCE                 // Signals reverse their direction.
+
+	MaxEdgeMode = BOUNCE
+)
+
+// edgeModeNames maps edge modes to their names.
+var edgeModeNames = map[EdgeMode]string{
+	KILL:   "kill",
+	WRAP:   "wrap",
+	BOUNCE: "bounce",
+}
+
+// Name returns the name of the edge mode.
+func (e EdgeMode) Name() string {
+	if name, ok := edgeModeNames[e]; ok {
+		return name
+	}
+	return edgeModeNames[KILL]
+}
+
+// nextPosition returns the position of the next cell in the given direction
+// following the grid edge mode, along with the direction to keep moving in.
+// It returns false when the next cell is out of the grid.
+func (g *Grid) nextPosition(x, y int, direction common.Direction) (int, int, common.Direction, bool) {
+	newX, newY := direction.NextPosition(x, y)
+	if !g.outOfBounds(newX, newY) {
+		return newX, newY, direction, true
+	}
+
+	switch g.Edge {
+	case WRAP:
+		return (newX + g.Width) % g.Width, (newY + g.Height) % g.Height, direction, true
+	case BOUNCE:
+		if newX < 0 || newX >= g.Width {
+			direction = direction.FlipHorizontal()
+		}
+		if newY < 0 || newY >= g.Height {
+			direction = direction.FlipVertical()
+		}
+		newX, newY = direction.NextPosition(x, y)
+		return newX, newY, direction, !g.outOfBounds(newX, newY)
+	default:
+		return newX, newY, direction, false
+	}
+}
@@ -1,7 +1,10 @@
 package field
 
 import (
+	"log"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"signls/core/common"
 	"signls/core/music"
@@ -9,21 +12,38 @@ import (
 	"signls/core/node"
 	"signls/core/theory"
 	"signls/midi"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
 )
 
 const (
 	defaultTempo                = 120.
 	defaultRootKey theory.Key   = 60
 	defaultScale   theory.Scale = theory.CHROMATIC
+
+	inputNotesBufferSize = 128
+
+	// Minimum number of keys held on the transpose channel to be
+	// considered as a chord.
+	minChordKeys = 3
 )
 
 // Grid represents the main structure for the grid-based sequencer.
+//
+// The grid is played by the clock goroutine while being edited from the
+// ui. Every change to the grid state must go through Edit and every read
+// through Read, so that it never happens in the middle of an update.
+// Update, Reset, Load, Save and TogglePlay take care of locking the grid
+// themselves and must not be called from Edit or Read.
 type Grid struct {
-	mu sync.Mutex
+	mu sync.RWMutex
 
 	midi      midi.Midi
 	device    midi.Device
+	input     midi.Device
 	clock     *common.Clock
+	rand      *common.Random  // Random source of the grid, seeded from the bank.
+	playback  *music.Playback // Playback state shared with the notes.
 	nodes     [][]common.Node
 	Height    int
 	Width     int
@@ -32,66 +52,140 @@ type Grid struct {
 	Key   theory.Key
 	Scale theory.Scale
 
-	Playing bool
+	playing atomic.Bool
+	fill    atomic.Bool // Fill mode state, copied to the playback state on each pulse.
 
 	SendClock     bool
 	SendTransport bool
+	ResetSeed     bool // Restart the random sequence from the seed when playback starts.
+
+	Edge EdgeMode // Behavior of signals reaching the grid edges.
+
+	TransposeChannel uint8 // Input channel transposing the root key (1-16), 0 when disabled.
+	TransposeScale   bool  // Chords held on the transpose channel also select the scale.
+	FillChannel      uint8 // Input channel holding fill mode while notes are held (1-16), 0 when disabled.
 
 	pulse uint64 // Global pulse counter for timing events
 
 	clipboard [][]common.Node
+
+	messages   <-chan gomidi.Message // Messages received from the midi input device.
+	inputNotes chan inputNote        // Notes received since the last step.
+	heldKeys   []theory.Key          // Keys held on the transpose channel.
+}
+
+// inputNote is a note received from the midi input device.
+type inputNote struct {
+	channel  uint8
+	key      uint8
+	velocity uint8
 }
 
 // NewGrid initializes and returns a new Grid with the given dimensions and MIDI interface.
 func NewGrid(width, height int, midi midi.Midi, device string) *Grid {
-	d := midi.NewDevice(device, "")
-	grid := &Grid{
-		midi:   midi,
-		device: d,
-		nodes:  make([][]common.Node, height),
-		Height: height,
-		Width:  width,
-		Key:    defaultRootKey,
-		Scale:  defaultScale,
-	}
-	for i := range grid.nodes {
-		grid.nodes[i] = make([]common.Node, width)
-	}
-
+	grid := newGrid(width, height, midi, device)
 	grid.clock = common.NewClock(defaultTempo, func() {
-		if !grid.Playing {
+		if !grid.Playing() {
 			return
 		}
-		if grid.SendClock {
-			grid.midi.SendClock(d.ID)
-		}
 		grid.Update()
 	})
+	return grid
+}
 
+// NewOfflineGrid initializes and returns a new Grid without any real-time
+// clock: it only plays when its caller updates it (ex: when rendering).
+func NewOfflineGrid(width, height int, midi midi.Midi, device string) *Grid {
+	grid := newGrid(width, height, midi, device)
+	grid.clock = common.NewManualClock(defaultTempo)
+	return grid
+}
+
+// newGrid initializes a grid without its clock.
+func newGrid(width, height int, midi midi.Midi, device string) *Grid {
+	d := midi.NewDevice(device, "")
+	rand := common.NewRandom(common.NewSeed())
+	grid := &Grid{
+		midi:       midi,
+		device:     d,
+		rand:       rand,
+		playback:   music.NewPlayback(rand),
+		nodes:      make([][]common.Node, height),
+		Height:     height,
+		Width:      width,
+		Key:        defaultRootKey,
+		Scale:      defaultScale,
+		inputNotes: make(chan inputNote, inputNotesBufferSize),
+	}
+	for i := range grid.nodes {
+		grid.nodes[i] = make([]common.Node, width)
+	}
 	return grid
 }
 
 // TogglePlay toggles the playing state of the grid.
 func (g *Grid) TogglePlay() {
-	g.Playing = !g.Playing
-	if !g.Playing {
+	g.mu.RLock()
+	resetSeed, sendTransport, device := g.ResetSeed, g.SendTransport, g.device.ID
+	g.mu.RUnlock()
+
+	playing := !g.Playing()
+	if playing {
+		g.clock.Reset()
+		if resetSeed {
+			g.rand.Reset()
+		}
+		g.playing.Store(true)
+	} else {
 		g.Reset()
 		g.midi.SilenceAll()
 	}
 
-	if !g.SendTransport {
+	if !sendTransport {
 		return
 	}
 
-	if g.Playing {
-		g.midi.TransportStart(g.device.ID)
+	if playing {
+		g.midi.TransportStart(device)
 	} else {
-		g.midi.TransportStop(g.device.ID)
+		g.midi.TransportStop(device)
 	}
 }
 
-// SetTempo sets the tempo of the grid.
+// Playing returns true if the grid is playing.
+func (g *Grid) Playing() bool {
+	return g.playing.Load()
+}
+
+// SetPlaying sets the playing state of the grid, without resetting it nor
+// sending any transport message. It's used to keep playing after loading
+// another grid.
+func (g *Grid) SetPlaying(playing bool) {
+	g.playing.Store(playing)
+}
+
+// Edit applies changes to the grid. It waits for the current update to
+// end, so that changes always happen between two clock pulses.
+func (g *Grid) Edit(edit func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	edit()
+}
+
+// Read reads the grid state, preventing any update or change while
+// reading.
+func (g *Grid) Read(read func()) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	read()
+}
+
+// SetTempo sets the tempo of the grid. The tempo cannot be changed
+// when following an external clock.
 func (g *Grid) SetTempo(tempo float64) {
+	if g.ExternalClock() {
+		return
+	}
 	g.clock.SetTempo(tempo)
 }
 
@@ -100,6 +194,40 @@ func (g *Grid) Tempo() float64 {
 	return g.clock.Tempo()
 }
 
+// SetSwing sets the swing amount of the grid, in percent (50-75).
+func (g *Grid) SetSwing(swing int) {
+	g.clock.SetSwing(swing)
+}
+
+// Swing returns the current swing amount, in percent.
+func (g *Grid) Swing() int {
+	return g.clock.Swing()
+}
+
+// Seed returns the seed of the random source.
+func (g *Grid) Seed() int64 {
+	return g.rand.Seed()
+}
+
+// SetSeed sets the seed of the random source and restarts its sequence.
+func (g *Grid) SetSeed(seed int64) {
+	if seed < 1 || seed > common.MaxSeed {
+		return
+	}
+	g.rand.SetSeed(seed)
+}
+
+// Reseed sets a new random seed.
+func (g *Grid) Reseed() {
+	g.rand.SetSeed(common.NewSeed())
+}
+
+// ClockStats returns the timing statistics of the grid clock since
+// playback started.
+func (g *Grid) ClockStats() common.ClockStats {
+	return g.clock.Stats()
+}
+
 // SetKey changes the root key of the grid and transposes all notes accordingly.
 func (g *Grid) SetKey(key theory.Key) {
 	g.Key = key
@@ -122,11 +250,134 @@ func (g *Grid) SetMidiDevice(device midi.Device) {
 	g.device = device
 }
 
+// InputDevice returns the currently active MIDI input device.
+func (g *Grid) InputDevice() midi.Device {
+	return g.input
+}
+
+// SetInputDevice sets the midi input device and starts listening to it.
+func (g *Grid) SetInputDevice(device midi.Device) {
+	if device == g.input && g.messages != nil {
+		return
+	}
+	if g.messages != nil {
+		g.midi.Unsubscribe(g.input.ID, g.messages)
+		g.messages = nil
+	}
+	g.input = device
+	if !device.Enabled() || device.Fallback {
+		return
+	}
+	messages, err := g.midi.Subscribe(device.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	g.messages = messages
+	go g.listen(messages)
+}
+
+// ExternalClock returns true if the grid follows the clock of the
+// midi input device.
+func (g *Grid) ExternalClock() bool {
+	return g.clock.External()
+}
+
+// SetExternalClock enables or disables the external clock mode.
+func (g *Grid) SetExternalClock(external bool) {
+	g.clock.SetExternal(external)
+}
+
+// listen handles midi messages coming from the midi input device until
+// the grid unsubscribes from it.
+func (g *Grid) listen(messages <-chan gomidi.Message) {
+	for msg := range messages {
+		g.receive(msg)
+	}
+}
+
+// receive handles a midi message coming from the midi input device.
+func (g *Grid) receive(msg gomidi.Message) {
+	g.mu.RLock()
+	transposeChannel, fillChannel := g.TransposeChannel, g.FillChannel
+	g.mu.RUnlock()
+
+	var channel, key, velocity uint8
+	switch {
+	case msg.GetNoteStart(&channel, &key, &velocity) && fillChannel == channel+1:
+		g.SetFill(true)
+		return
+	case msg.GetNoteEnd(&channel, &key) && fillChannel == channel+1:
+		g.SetFill(false)
+		return
+	case msg.GetNoteStart(&channel, &key, &velocity) && transposeChannel == channel+1:
+		g.transposeFromInput(theory.Key(key))
+		return
+	case msg.GetNoteEnd(&channel, &key) && transposeChannel == channel+1:
+		g.Edit(func() {
+			g.heldKeys = slices.DeleteFunc(g.heldKeys, func(k theory.Key) bool {
+				return k == theory.Key(key)
+			})
+		})
+		return
+	case msg.GetNoteStart(&channel, &key, &velocity):
+		if !g.Playing() {
+			return
+		}
+		select {
+		case g.inputNotes <- inputNote{channel: channel, key: key, velocity: velocity}:
+		default:
+		}
+		return
+	}
+	if !g.ExternalClock() {
+		return
+	}
+	switch msg.Type() {
+	case gomidi.TimingClockMsg:
+		g.clock.Pulse()
+	case gomidi.StartMsg:
+		if g.Playing() {
+			g.TogglePlay()
+		}
+		g.TogglePlay()
+	case gomidi.ContinueMsg:
+		g.SetPlaying(true)
+	case gomidi.StopMsg:
+		if g.Playing() {
+			g.TogglePlay()
+		}
+	}
+}
+
+// Fill returns true when fill mode is on.
+func (g *Grid) Fill() bool {
+	return g.fill.Load()
+}
+
+// SetFill turns fill mode on or off from the next pulse. Fill mode is shared
+// by all the notes of the grid, it's played by nodes flagged for fill and by
+// fill trig conditions.
+func (g *Grid) SetFill(on bool) {
+	g.fill.Store(on)
+}
+
+// ToggleFill latches fill mode on or off.
+func (g *Grid) ToggleFill() {
+	g.SetFill(!g.Fill())
+}
+
 // Midi returns the Midi interface.
 func (g *Grid) Midi() midi.Midi {
 	return g.midi
 }
 
+// Playback returns the playback state shared by the grid with its nodes.
+// Nodes added to the grid must be created with it.
+func (g *Grid) Playback() *music.Playback {
+	return g.playback
+}
+
 // Pulse returns the current pulse step.
 func (g *Grid) Pulse() uint64 {
 	return g.pulse / uint64(common.PulsesPerStep)
@@ -134,7 +385,7 @@ func (g *Grid) Pulse() uint64 {
 
 // QuarterNote checks if the current pulse aligns with a quarter note.
 func (g *Grid) QuarterNote() bool {
-	if !g.Playing {
+	if !g.Playing() {
 		return false
 	}
 	return g.pulse/uint64(common.PulsesPerStep)%uint64(common.StepsPerQuarterNote) == 0
@@ -195,23 +446,33 @@ func (g *Grid) Node(x, y int) common.Node {
 func (g *Grid) AddNodeFromSymbol(symbol string, x, y int) {
 	switch symbol {
 	case "b":
-		g.AddNode(node.NewBangEmitter(g.midi, &g.device, common.NONE, !g.Playing), x, y)
+		g.AddNode(node.NewBangEmitter(g.midi, &g.device, g.playback, common.NONE, !g.Playing()), x, y)
 	case "s":
-		g.AddNode(node.NewSpreadEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewSpreadEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "c":
-		g.AddNode(node.NewCycleEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewCycleEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "d":
-		g.AddNode(node.NewDiceEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewDiceEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "t":
-		g.AddNode(node.NewTollEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewTollEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "e":
-		g.AddNode(node.NewEuclidEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewEuclidEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "z":
-		g.AddNode(node.NewZoneEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewZoneEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "p":
-		g.AddNode(node.NewPassEmitter(g.midi, &g.device, common.NONE), x, y)
+		g.AddNode(node.NewPassEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
 	case "h":
 		g.AddNode(node.NewHoleEmitter(common.NONE, x, y, g.Width, g.Height), x, y)
+	case "k":
+		g.AddNode(node.NewChordEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
+	case "a":
+		g.AddNode(node.NewArpEmitter(g.midi, &g.device, g.playback, common.NONE), x, y)
+	case "m":
+		g.AddNode(node.NewMirror(false), x, y)
+	case "r":
+		g.AddNode(node.NewRotator(true), x, y)
+	case "v":
+		g.AddNode(node.NewValve(common.NONE), x, y)
 	}
 }
 
@@ -263,9 +524,13 @@ func (g *Grid) SetAllNodeMutes(mute bool) {
 func (g *Grid) Update() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	if g.pulse%uint64(common.PulsesPerStep) != 0 {
-		g.Tick()
-		return
+	if g.SendClock {
+		g.midi.SendClock(g.device.ID)
+	}
+	g.playback.Fill = g.Fill()
+	g.playback.Pulse = g.pulse
+	if common.NORMAL.IsStep(g.pulse) {
+		g.TriggerInputNotes()
 	}
 	for y := g.Height - 1; y >= 0; y-- {
 		for x := g.Width - 1; x >= 0; x-- {
@@ -277,30 +542,65 @@ func (g *Grid) Update() {
 				n.Tick()
 			}
 
+			// Nodes running at their own rate only move and emit on
+			// their own steps.
+			if !nodeRate(g.nodes[y][x]).IsStep(g.pulse) {
+				continue
+			}
+
 			if n, ok := g.nodes[y][x].(common.Movable); ok {
 				g.Move(n, x, y)
 			}
 
 			if n, ok := g.nodes[y][x].(music.Audible); ok {
-				n.Trig(g.Key, g.Scale, common.NONE, g.pulse)
+				n.Trig(g.Key, g.Scale, common.NONE, common.Payload{}, g.pulse)
 				g.ExecuteMetaCommands(n)
 				g.Emit(n, x, y)
 			}
 		}
 	}
+	g.playback.Flush()
 	g.pulse++
 }
 
-// Tick updates all active notes within the grid on every pulse.
-func (g *Grid) Tick() {
-	for y := 0; y < g.Height; y++ {
-		for x := 0; x < g.Width; x++ {
-			if n, ok := g.nodes[y][x].(common.Tickable); ok {
-				n.Tick()
+// transposeFromInput changes the root key from a key received on the
+// transpose channel. When enabled, chords held on the transpose channel
+// also select the closest matching scale, with the lowest key as root.
+func (g *Grid) transposeFromInput(key theory.Key) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.heldKeys = append(g.heldKeys, key)
+	if g.TransposeScale && len(g.heldKeys) >= minChordKeys {
+		key = slices.Min(g.heldKeys)
+		g.Scale = theory.ClosestScale(key, g.heldKeys)
+	}
+	g.Key = key
+	g.Transpose()
+}
+
+// TriggerInputNotes arms and triggers every emitter matching the notes
+// received from the midi input device since the last step.
+func (g *Grid) TriggerInputNotes() {
+	for {
+		select {
+		case in := <-g.inputNotes:
+			for y := 0; y < g.Height; y++ {
+				for x := 0; x < g.Width; x++ {
+					n, ok := g.nodes[y][x].(music.Audible)
+					if !ok || !n.Note().Input.Match(in.channel, in.key) {
+						continue
+					}
+					if n.Note().Input.Velocity {
+						n.Note().SetInputVelocity(in.velocity)
+					}
+					n.Arm()
+					n.Trig(g.Key, g.Scale, common.NONE, common.Payload{}, g.pulse)
+				}
 			}
+		default:
+			return
 		}
 	}
-	g.pulse++
 }
 
 // Transpose transposes all notes in the grid to match the current key and scale.
@@ -318,8 +618,10 @@ func (g *Grid) Transpose() {
 func (g *Grid) Reset() {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.Playing = false
+	g.playing.Store(false)
 	g.pulse = 0
+	g.clock.Reset()
+	g.playback.Reset()
 	for y := 0; y < g.Height; y++ {
 		for x := 0; x < g.Width; x++ {
 			if _, ok := g.nodes[y][x].(common.Movable); ok {
@@ -335,26 +637,30 @@ func (g *Grid) Reset() {
 
 // Emit makes specified emitter generates signals.
 func (g *Grid) Emit(emitter music.Audible, x, y int) {
-	for _, direction := range emitter.Emit(g.pulse) {
-		newX, newY := direction.NextPosition(x, y)
-		if (newX == x && newY == y) || g.outOfBounds(newX, newY) {
+	directions := emitter.Emit(g.pulse)
+	if !emitter.Note().Fill.Active(g.playback.Fill) {
+		return
+	}
+	for _, direction := range directions {
+		newX, newY, direction, ok := g.route(x, y, direction)
+		if !ok || (newX == x && newY == y) {
 			continue
 		}
 
 		if n, ok := g.nodes[newY][newX].(common.Behavioral); ok && n.Behavior().ShouldPropagate() {
-			g.PropagateZone(g.nodes[newY][newX].(*node.Emitter), direction, newX, newY)
+			g.PropagateZone(g.nodes[newY][newX].(*node.Emitter), direction, nodePayload(emitter), newX, newY)
 			continue
 		} else if n, ok := g.nodes[newY][newX].(music.Audible); ok {
 			n.Arm()
-			n.Trig(g.Key, g.Scale, direction, g.pulse)
+			n.Trig(g.Key, g.Scale, direction, nodePayload(emitter), g.pulse)
 			continue
 		} else if n, ok := g.nodes[newY][newX].(*node.HoleEmitter); ok {
-			g.Teleport(n, node.NewSignal(direction, g.pulse), newX, newY)
+			g.Teleport(n, g.newSignal(emitter, direction), newX, newY)
 			continue
 		} else if n, ok := g.nodes[newY][newX].(*node.Signal); ok {
 			g.Move(n, newX, newY)
 		}
-		g.nodes[newY][newX] = node.NewSignal(direction, g.pulse)
+		g.nodes[newY][newX] = g.newSignal(emitter, direction)
 	}
 }
 
@@ -366,57 +672,104 @@ func (g *Grid) ExecuteMetaCommands(node music.Audible) {
 		}
 		switch c := cmd.(type) {
 		case *meta.RootCommand:
-			g.Key = theory.Key(c.Value().Computed())
+			g.Key = theory.Key(c.Value().Computed(g.rand))
 		case *meta.ScaleCommand:
-			g.Scale = theory.AllScales()[c.Value().Computed()]
+			g.Scale = theory.AllScales()[c.Value().Computed(g.rand)]
 		case *meta.TempoCommand:
-			g.SetTempo(float64(c.Value().Computed()))
+			g.SetTempo(float64(c.Value().Computed(g.rand)))
 		case *meta.BankCommand:
-			g.BankIndex = c.Value().Computed()
+			g.BankIndex = c.Value().Computed(g.rand)
 		}
 
 		cmd.Reset()
 	}
 }
 
-// Move moves a node in the specified direction.
+// Move moves a node in the specified direction, by as many cells as its
+// speed allows. Nodes that reached the end of their lifetime are removed.
 func (g *Grid) Move(movable common.Movable, x, y int) {
 	if !movable.MustMove(g.pulse) {
 		return
 	}
 
-	direction := movable.(common.Node).Direction()
-	newX, newY := direction.NextPosition(x, y)
-
-	if g.outOfBounds(newX, newY) {
+	if movable.Expired() {
 		g.nodes[y][x] = nil
 		return
 	}
 
+	for range movable.Cells() {
+		var moving bool
+		x, y, moving = g.moveCell(movable, x, y)
+		if !moving {
+			return
+		}
+	}
+}
+
+// moveCell moves a node to the next cell in its direction. It returns the
+// new node position and whether the node is still moving on the grid.
+func (g *Grid) moveCell(movable common.Movable, x, y int) (int, int, bool) {
+	newX, newY, direction, ok := g.route(x, y, movable.(common.Node).Direction())
+	if !ok {
+		g.nodes[y][x] = nil
+		return x, y, false
+	}
+	movable.(common.Node).SetDirection(direction)
+	if newX == x && newY == y {
+		return x, y, true
+	}
+
 	if g.nodes[newY][newX] == nil {
 		g.nodes[newY][newX] = g.nodes[y][x]
+		g.nodes[y][x] = nil
+		return newX, newY, true
 	} else if n, ok := g.nodes[newY][newX].(common.Behavioral); ok && n.Behavior().ShouldPropagate() {
-		g.PropagateZone(g.nodes[newY][newX].(*node.Emitter), direction, newX, newY)
+		g.PropagateZone(g.nodes[newY][newX].(*node.Emitter), direction, nodePayload(movable), newX, newY)
 	} else if n, ok := g.nodes[newY][newX].(music.Audible); ok {
 		n.Arm()
-		n.Trig(g.Key, g.Scale, direction, g.pulse)
+		n.Trig(g.Key, g.Scale, direction, nodePayload(movable), g.pulse)
 	} else if n, ok := g.nodes[newY][newX].(*node.HoleEmitter); ok {
 		g.Teleport(n, g.nodes[y][x], newX, newY)
 	} else if n, ok := g.nodes[newY][newX].(*node.Signal); ok {
 		g.Move(n, newX, newY)
 		g.nodes[newY][newX] = g.nodes[y][x]
+		g.nodes[y][x] = nil
+		return newX, newY, true
 	}
 
 	g.nodes[y][x] = nil
+	return x, y, false
+}
+
+// route returns the position of the next cell in the given direction, going
+// through the routers redirecting signals on the way. It returns false when
+// the signal is blocked, trapped between routers or out of the grid.
+func (g *Grid) route(x, y int, direction common.Direction) (int, int, common.Direction, bool) {
+	newX, newY, direction, ok := g.nextPosition(x, y, direction)
+	for range g.Width * g.Height {
+		if !ok {
+			return newX, newY, direction, false
+		}
+		r, isRouter := g.nodes[newY][newX].(common.Router)
+		if !isRouter {
+			return newX, newY, direction, true
+		}
+		direction = r.Route(direction)
+		if direction == common.NONE {
+			return newX, newY, direction, false
+		}
+		newX, newY, direction, ok = g.nextPosition(newX, newY, direction)
+	}
+	return newX, newY, direction, false
 }
 
 // PropagateZone propagates a trigger to neighboring nodes.
-func (g *Grid) PropagateZone(e *node.Emitter, direction common.Direction, x, y int) {
+func (g *Grid) PropagateZone(e *node.Emitter, direction common.Direction, payload common.Payload, x, y int) {
 	if e == nil {
 		return
 	}
 	e.Arm()
-	e.Trig(g.Key, g.Scale, direction, g.pulse)
+	e.Trig(g.Key, g.Scale, direction, payload, g.pulse)
 	for dy := -1; dy <= 1; dy++ {
 		for dx := -1; dx <= 1; dx++ {
 			newX, newY := x+dx, y+dy
@@ -425,12 +778,12 @@ func (g *Grid) PropagateZone(e *node.Emitter, direction common.Direction, x, y i
 				continue
 			}
 			if n, ok := g.nodes[newY][newX].(*node.Emitter); ok && !n.Activated() && n.Behavior().ShouldPropagate() {
-				g.PropagateZone(n, direction, newX, newY)
+				g.PropagateZone(n, direction, payload, newX, newY)
 			} else if n, ok := g.nodes[newY][newX].(*node.Emitter); ok && !n.Activated() {
 				n.Arm()
-				n.Trig(g.Key, g.Scale, direction, g.pulse)
+				n.Trig(g.Key, g.Scale, direction, payload, g.pulse)
 			} else if n, ok := g.nodes[newY][newX].(*node.HoleEmitter); ok {
-				g.Teleport(n, node.NewSignal(direction, g.pulse), newX, newY)
+				g.Teleport(n, g.newSignal(e, direction), newX, newY)
 			}
 		}
 	}
@@ -438,7 +791,7 @@ func (g *Grid) PropagateZone(e *node.Emitter, direction common.Direction, x, y i
 
 // Teleport moves a node through a Hole emitter.
 func (g *Grid) Teleport(t *node.HoleEmitter, m common.Node, x, y int) {
-	teleportX, teleportY := t.Teleport()
+	teleportX, teleportY := t.Teleport(g.rand)
 	if g.outOfBounds(teleportX, teleportY) {
 		return
 	}
@@ -447,7 +800,7 @@ func (g *Grid) Teleport(t *node.HoleEmitter, m common.Node, x, y int) {
 	}
 	if n, ok := g.nodes[teleportY][teleportX].(music.Audible); ok {
 		n.Arm()
-		n.Trig(g.Key, g.Scale, common.NONE, g.pulse)
+		n.Trig(g.Key, g.Scale, common.NONE, nodePayload(m), g.pulse)
 	} else if n, ok := g.nodes[teleportY][teleportX].(*node.HoleEmitter); ok {
 		g.Teleport(n, m, teleportX, teleportY)
 	} else if g.nodes[teleportY][teleportX] == nil {
@@ -487,3 +840,34 @@ func (g *Grid) Resize(newWidth, newHeight int) {
 func (g *Grid) outOfBounds(x, y int) bool {
 	return x >= g.Width || y >= g.Height || x < 0 || y < 0
 }
+
+// newSignal creates a signal emitted by a node in the given direction, with
+// the rate, speed and lifetime of the emitting node.
+func (g *Grid) newSignal(emitter any, direction common.Direction) *node.Signal {
+	var (
+		speed    common.Speed
+		lifetime int
+	)
+	if s, ok := emitter.(common.Signaling); ok {
+		speed, lifetime = s.Speed(), s.Lifetime()
+	}
+	return node.NewSignal(direction, g.pulse, nodeRate(emitter), speed, lifetime, nodePayload(emitter))
+}
+
+// nodePayload returns the payload carried by a node, nodes without payload
+// carry an empty one.
+func nodePayload(n any) common.Payload {
+	if c, ok := n.(common.Carrier); ok {
+		return c.Payload()
+	}
+	return common.Payload{}
+}
+
+// nodeRate returns the rate of a node, nodes without their own rate run at the
+// grid speed.
+func nodeRate(n any) common.Rate {
+	if r, ok := n.(common.Rated); ok {
+		return r.Rate()
+	}
+	return common.NORMAL
+}
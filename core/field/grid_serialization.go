@@ -11,13 +11,28 @@ import (
 	"signls/midi"
 )
 
+// legacyInfiniteLength is the length of infinite notes in legacy grids,
+// saved before lengths were extended to bars.
+const legacyInfiniteLength = 127
+
 func NewFromBank(bankIndex int, grid filesystem.Grid, midi midi.Midi) *Grid {
 	newGrid := NewGrid(grid.Width, grid.Height, midi, grid.Device)
 	newGrid.Load(bankIndex, grid)
 	return newGrid
 }
 
+// NewOfflineFromBank creates a grid from a bank grid without any real-time
+// clock, see NewOfflineGrid.
+func NewOfflineFromBank(bankIndex int, grid filesystem.Grid, midi midi.Midi) *Grid {
+	newGrid := NewOfflineGrid(grid.Width, grid.Height, midi, grid.Device)
+	newGrid.Load(bankIndex, grid)
+	return newGrid
+}
+
 func (g *Grid) Save(bank *filesystem.Bank) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	nodes := []filesystem.Node{}
 
 	for y := range g.nodes {
@@ -65,11 +80,40 @@ func (g *Grid) Save(bank *filesystem.Bank) {
 				fnode.Params = map[string]filesystem.Param{
 					"threshold": filesystem.NewParam(*n.(common.Behavioral).Behavior().(*node.TollEmitter).Threshold),
 				}
+			case "chord":
+				fnode.Params = newChordParams(n.(common.Behavioral).Behavior().(music.Chorded).Chord())
+			case "arp":
+				arp := n.(*node.ArpEmitter)
+				fnode.Params = newChordParams(arp.Chord())
+				fnode.Params["pattern"] = filesystem.NewParam(*arp.Pattern)
+				fnode.Params["octaves"] = filesystem.NewParam(*arp.Octaves)
+				fnode.Params["gate"] = filesystem.NewParam(*arp.Gate)
+				fnode.Params["emit_each"] = filesystem.NewBoolParam(arp.EmitEach)
 			case "hole":
 				fnode.Params = map[string]filesystem.Param{
 					"destinationX": filesystem.NewParam(*n.(*node.HoleEmitter).DestinationX),
 					"destinationY": filesystem.NewParam(*n.(*node.HoleEmitter).DestinationY),
 				}
+			case "mirror":
+				fnode.Params = map[string]filesystem.Param{
+					"backslash": filesystem.NewBoolParam(n.(*node.Mirror).Backslash),
+				}
+			case "rotator":
+				fnode.Params = map[string]filesystem.Param{
+					"clockwise": filesystem.NewBoolParam(n.(*node.Rotator).Clockwise),
+				}
+			}
+
+			if r, ok := n.(common.Rated); ok {
+				fnode.Params["rate"] = filesystem.Param{Value: int(r.Rate())}
+			}
+			if s, ok := n.(common.Signaling); ok {
+				fnode.Params["speed"] = filesystem.Param{Value: int(s.Speed())}
+				fnode.Params["lifetime"] = filesystem.Param{Value: s.Lifetime()}
+			}
+			if c, ok := n.(common.Carrier); ok {
+				fnode.Params["send_transpose"] = filesystem.Param{Value: c.Payload().Transpose}
+				fnode.Params["send_velocity"] = filesystem.Param{Value: c.Payload().Velocity}
 			}
 
 			nodes = append(nodes, fnode)
@@ -77,8 +121,10 @@ func (g *Grid) Save(bank *filesystem.Bank) {
 	}
 
 	bank.Save(filesystem.Grid{
+		Version:       filesystem.GridVersion,
 		Nodes:         nodes,
 		Tempo:         g.Tempo(),
+		Swing:         g.Swing(),
 		Height:        g.Height,
 		Width:         g.Width,
 		Device:        g.device.Name,
@@ -86,6 +132,15 @@ func (g *Grid) Save(bank *filesystem.Bank) {
 		Scale:         uint16(g.Scale),
 		SendClock:     g.SendClock,
 		SendTransport: g.SendTransport,
+		ExternalClock: g.ExternalClock(),
+		InputDevice:   g.input.Name,
+		Seed:          g.Seed(),
+		ResetSeed:     g.ResetSeed,
+		Edge:          int(g.Edge),
+
+		TransposeChannel: int(g.TransposeChannel),
+		TransposeScale:   g.TransposeScale,
+		FillChannel:      int(g.FillChannel),
 	})
 }
 
@@ -98,11 +153,24 @@ func (g *Grid) Load(index int, grid filesystem.Grid) {
 
 	g.BankIndex = index
 	g.device = g.midi.NewDevice(grid.Device, "")
+	g.SetInputDevice(g.midi.NewInput(grid.InputDevice))
 	g.clock.SetTempo(grid.Tempo)
+	g.clock.SetSwing(max(grid.Swing, common.SwingMin))
+	g.SetExternalClock(grid.ExternalClock)
 	g.Key = theory.Key(grid.Key)
 	g.Scale = theory.Scale(grid.Scale)
 	g.SendClock = grid.SendClock
 	g.SendTransport = grid.SendTransport
+	g.ResetSeed = grid.ResetSeed
+	if grid.Seed > 0 {
+		g.SetSeed(grid.Seed)
+	} else {
+		g.Reseed()
+	}
+	g.Edge = EdgeMode(grid.Edge)
+	g.TransposeChannel = uint8(grid.TransposeChannel)
+	g.TransposeScale = grid.TransposeScale
+	g.FillChannel = uint8(grid.FillChannel)
 	g.Resize(grid.Width, grid.Height)
 
 	g.nodes = make([][]common.Node, g.Height)
@@ -114,9 +182,9 @@ func (g *Grid) Load(index int, grid filesystem.Grid) {
 		var newNode common.Node
 		switch n.Type {
 		case "bang":
-			newNode = node.NewBangEmitter(g.midi, &g.device, common.Direction(n.Direction), true)
+			newNode = node.NewBangEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction), true)
 		case "euclid":
-			newNode = node.NewEuclidEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewEuclidEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 			newNode.(*node.EuclidEmitter).Steps.Set(n.Params["steps"].Value)
 			newNode.(*node.EuclidEmitter).Steps.SetRandomAmount(n.Params["steps"].Amount)
 			newNode.(*node.EuclidEmitter).Triggers.Set(n.Params["triggers"].Value)
@@ -124,46 +192,97 @@ func (g *Grid) Load(index int, grid filesystem.Grid) {
 			newNode.(*node.EuclidEmitter).Offset.Set(n.Params["offset"].Value)
 			newNode.(*node.EuclidEmitter).Offset.SetRandomAmount(n.Params["offset"].Amount)
 		case "pass":
-			newNode = node.NewPassEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewPassEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 		case "spread":
-			newNode = node.NewSpreadEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewSpreadEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 		case "cycle":
-			newNode = node.NewCycleEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewCycleEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 			newNode.(common.Behavioral).Behavior().(*node.CycleEmitter).Repeat().Set(n.Params["repeat"].Value)
 			newNode.(common.Behavioral).Behavior().(*node.CycleEmitter).Repeat().SetRandomAmount(n.Params["repeat"].Amount)
 		case "dice":
-			newNode = node.NewDiceEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewDiceEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 			newNode.(common.Behavioral).Behavior().(*node.DiceEmitter).Repeat().Set(n.Params["repeat"].Value)
 			newNode.(common.Behavioral).Behavior().(*node.DiceEmitter).Repeat().SetRandomAmount(n.Params["repeat"].Amount)
 		case "toll":
-			newNode = node.NewTollEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewTollEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 			newNode.(common.Behavioral).Behavior().(*node.TollEmitter).Threshold.Set(n.Params["threshold"].Value)
 			newNode.(common.Behavioral).Behavior().(*node.TollEmitter).Threshold.SetRandomAmount(n.Params["threshold"].Amount)
+		case "chord":
+			newNode = node.NewChordEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
+			loadChord(newNode.(common.Behavioral).Behavior().(music.Chorded).Chord(), n.Params)
+		case "arp":
+			arp := node.NewArpEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
+			loadChord(arp.Chord(), n.Params)
+			loadControl(arp.Pattern, n.Params["pattern"])
+			if n.Params["octaves"].Value > 0 {
+				loadControl(arp.Octaves, n.Params["octaves"])
+			}
+			if n.Params["gate"].Value > 0 {
+				loadControl(arp.Gate, n.Params["gate"])
+			}
+			arp.EmitEach = n.Params["emit_each"].Bool()
+			newNode = arp
 		case "zone":
-			newNode = node.NewZoneEmitter(g.midi, &g.device, common.Direction(n.Direction))
+			newNode = node.NewZoneEmitter(g.midi, &g.device, g.playback, common.Direction(n.Direction))
 		case "hole":
 			newNode = node.NewHoleEmitter(common.Direction(n.Direction), n.X, n.Y, g.Width, g.Height)
 			newNode.(*node.HoleEmitter).DestinationX.Set(n.Params["destinationX"].Value)
 			newNode.(*node.HoleEmitter).DestinationX.SetRandomAmount(n.Params["destinationX"].Amount)
 			newNode.(*node.HoleEmitter).DestinationY.Set(n.Params["destinationY"].Value)
 			newNode.(*node.HoleEmitter).DestinationY.SetRandomAmount(n.Params["destinationY"].Amount)
+		case "mirror":
+			newNode = node.NewMirror(n.Params["backslash"].Bool())
+		case "rotator":
+			newNode = node.NewRotator(n.Params["clockwise"].Bool())
+		case "valve":
+			newNode = node.NewValve(common.Direction(n.Direction))
 		default:
 			log.Printf("cannot load node of type %s", n.Type)
 			continue
 		}
 
+		if r, ok := newNode.(common.Rated); ok {
+			r.SetRate(common.Rate(n.Params["rate"].Value))
+		}
+		if s, ok := newNode.(common.Signaling); ok {
+			s.SetSpeed(common.Speed(n.Params["speed"].Value))
+			s.SetLifetime(n.Params["lifetime"].Value)
+		}
+		if c, ok := newNode.(common.Carrier); ok {
+			c.SetPayload(common.Payload{
+				Transpose: n.Params["send_transpose"].Value,
+				Velocity:  n.Params["send_velocity"].Value,
+			})
+		}
+
 		if a, ok := newNode.(music.Audible); ok {
 			a.SetMute(n.Muted)
 			a.Note().SetKey(theory.Key(n.Note.Key.Key), g.Key)
 			a.Note().Key.SetRandomAmount(n.Note.Key.Amount)
+			a.Note().Key.SetDistribution(common.Distribution(n.Note.Key.Distribution))
 			a.Note().Key.SetSilent(n.Note.Key.Silent)
-			a.Note().Channel.Set(uint8(n.Note.Channel.Value))
-			a.Note().Channel.SetRandomAmount(n.Note.Channel.Amount)
-			a.Note().Velocity.Set(uint8(n.Note.Velocity.Value))
-			a.Note().Velocity.SetRandomAmount(n.Note.Velocity.Amount)
-			a.Note().Length.Set(uint8(n.Note.Length.Value))
-			a.Note().Length.SetRandomAmount(n.Note.Length.Amount)
+			a.Note().Key.Sequence().Set(n.Note.Key.Sequence, common.SequenceMode(n.Note.Key.Mode))
+			loadControl(a.Note().Channel, n.Note.Channel)
+			loadControl(a.Note().Velocity, n.Note.Velocity)
+			loadLength(a.Note().Length, n.Note.Length, grid.Version)
+			a.Note().Legato = n.Note.Legato
 			a.Note().Probability = uint8(n.Note.Probability)
+			a.Note().Condition = music.Condition{
+				Type: music.ConditionType(n.Note.Condition.Type),
+				A:    n.Note.Condition.A,
+				B:    n.Note.Condition.B,
+			}
+			a.Note().Fill = music.FillMode(n.Note.Fill)
+			a.Note().Nudge = min(max(n.Note.Nudge, music.MinNudge), music.MaxNudge)
+			a.Note().Ratchet = music.Ratchet{
+				Count: min(max(n.Note.Ratchet.Count, 1), music.MaxRatchets),
+				Rate:  min(max(n.Note.Ratchet.Rate, 0), music.MaxRatchetRate),
+				Decay: min(max(n.Note.Ratchet.Decay, 0), music.MaxRatchetDecay),
+			}
+			a.Note().Input.Active = n.Note.Input.Active
+			a.Note().Input.SetKey(theory.Key(n.Note.Input.Key))
+			a.Note().Input.SetChannel(uint8(n.Note.Input.Channel))
+			a.Note().Input.Velocity = n.Note.Input.Velocity
 
 			device := g.midi.NewDevice(n.Device, g.device.Name)
 			a.Note().Device.Device = device
@@ -172,8 +291,7 @@ func (g *Grid) Load(index int, grid filesystem.Grid) {
 			for i, c := range n.Note.Controls {
 				a.Note().Controls[i].Type = music.ControlType(c.Type)
 				a.Note().Controls[i].Controller = uint8(c.Controller)
-				a.Note().Controls[i].Value.Set(uint8(c.Value.Value))
-				a.Note().Controls[i].Value.SetRandomAmount(c.Value.Amount)
+				loadControl(a.Note().Controls[i].Value, c.Value)
 			}
 
 			for _, c := range a.Note().MetaCommands {
@@ -187,3 +305,57 @@ func (g *Grid) Load(index int, grid filesystem.Grid) {
 		g.nodes[n.Y][n.X] = newNode
 	}
 }
+
+// newChordParams returns the serialized parameters of a chord.
+func newChordParams(chord *music.Chord) map[string]filesystem.Param {
+	return map[string]filesystem.Param{
+		"chord":     filesystem.NewParam(*chord.Type),
+		"voicing":   filesystem.NewParam(*chord.Voicing),
+		"inversion": filesystem.NewParam(*chord.Inversion),
+		"strum":     filesystem.NewParam(*chord.Strum),
+	}
+}
+
+// loadChord sets a chord from its serialized parameters, keeping the
+// default chord type when none was saved.
+func loadChord(chord *music.Chord, params map[string]filesystem.Param) {
+	if params["chord"].Value > 0 {
+		loadControl(chord.Type, params["chord"])
+	}
+	loadControl(chord.Voicing, params["voicing"])
+	loadControl(chord.Inversion, params["inversion"])
+	loadControl(chord.Strum, params["strum"])
+}
+
+// loadLength loads a note length, converting infinite lengths of legacy
+// grids.
+func loadLength(c *common.ControlValue[int], p filesystem.Param, version int) {
+	if version > filesystem.LegacyGridVersion {
+		loadControl(c, p)
+		return
+	}
+	if p.Value == legacyInfiniteLength {
+		p.Value = music.InfiniteLength
+	}
+	sequence := make([]int, len(p.Sequence))
+	for i, v := range p.Sequence {
+		if v == legacyInfiniteLength {
+			v = music.InfiniteLength
+		}
+		sequence[i] = v
+	}
+	p.Sequence = sequence
+	loadControl(c, p)
+}
+
+// loadControl sets a control value from its serialized parameter.
+func loadControl[T uint8 | int](c *common.ControlValue[T], p filesystem.Param) {
+	c.Set(T(p.Value))
+	c.SetRandomAmount(p.Amount)
+	c.SetDistribution(common.Distribution(p.Distribution))
+	sequence := make([]T, len(p.Sequence))
+	for i, v := range p.Sequence {
+		sequence[i] = T(v)
+	}
+	c.SetSequence(sequence, common.SequenceMode(p.Mode))
+}
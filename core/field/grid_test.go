@@ -2,11 +2,18 @@ package field
 
 import (
 	"fmt"
+	"path/filepath"
+	"slices"
 	"testing"
 
 	"signls/core/common"
+	"signls/core/music"
 	"signls/core/node"
+	"signls/core/theory"
+	"signls/filesystem"
 	"signls/midi"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
 )
 
 var benchmarks = []struct {
@@ -26,14 +33,14 @@ func BenchmarkGrid(b *testing.B) {
 		b.Run(fmt.Sprintf("grid_size_%dx%d", v.size, v.size), func(b *testing.B) {
 			grid := NewGrid(v.size, v.size, midi, "")
 			device := midi.NewDevice("", "")
-			grid.AddNode(node.NewBangEmitter(midi, &device, common.DOWN|common.RIGHT, true), 7, 7)
-			grid.AddNode(node.NewSpreadEmitter(midi, &device, common.DOWN), 11, 7)
-			grid.AddNode(node.NewSpreadEmitter(midi, &device, common.LEFT), 11, 11)
-			grid.AddNode(node.NewSpreadEmitter(midi, &device, common.UP), 7, 11)
-			grid.AddNode(node.NewBangEmitter(midi, &device, common.RIGHT, true), 7, 2)
-			grid.AddNode(node.NewSpreadEmitter(midi, &device, common.LEFT), 12, 2)
-			grid.AddNode(node.NewBangEmitter(midi, &device, common.RIGHT, true), 7, 3)
-			grid.AddNode(node.NewSpreadEmitter(midi, &device, common.LEFT), 9, 3)
+			grid.AddNode(node.NewBangEmitter(midi, &device, grid.Playback(), common.DOWN|common.RIGHT, true), 7, 7)
+			grid.AddNode(node.NewSpreadEmitter(midi, &device, grid.Playback(), common.DOWN), 11, 7)
+			grid.AddNode(node.NewSpreadEmitter(midi, &device, grid.Playback(), common.LEFT), 11, 11)
+			grid.AddNode(node.NewSpreadEmitter(midi, &device, grid.Playback(), common.UP), 7, 11)
+			grid.AddNode(node.NewBangEmitter(midi, &device, grid.Playback(), common.RIGHT, true), 7, 2)
+			grid.AddNode(node.NewSpreadEmitter(midi, &device, grid.Playback(), common.LEFT), 12, 2)
+			grid.AddNode(node.NewBangEmitter(midi, &device, grid.Playback(), common.RIGHT, true), 7, 3)
+			grid.AddNode(node.NewSpreadEmitter(midi, &device, grid.Playback(), common.LEFT), 9, 3)
 			grid.TogglePlay()
 			for i := 0; i < b.N; i++ {
 				grid.Update()
@@ -41,3 +48,549 @@ func BenchmarkGrid(b *testing.B) {
 		})
 	}
 }
+
+// TestConcurrentEdit edits the grid while it's being played, it's meant to
+// be run with the race detector.
+func TestConcurrentEdit(t *testing.T) {
+	grid := NewGrid(20, 20, &midi.Mock{}, "")
+	grid.SetTempo(300)
+	grid.TogglePlay()
+	defer grid.TogglePlay()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 1000 {
+			grid.Update()
+		}
+	}()
+
+	symbols := []string{"b", "s", "c", "d", "t", "e", "z", "p", "h", "k", "a"}
+	for i := range 200 {
+		x, y := i%18+1, i/18+1
+		grid.Edit(func() {
+			grid.AddNodeFromSymbol(symbols[i%len(symbols)], x, y)
+			grid.SetKey(theory.Key(60 + i%12))
+		})
+		grid.Read(func() {
+			_ = grid.Node(x, y)
+			_ = grid.Pulse()
+		})
+	}
+	grid.Save(filesystem.New(filepath.Join(t.TempDir(), "bank.json")))
+	grid.Edit(func() {
+		grid.CopyOrCut(0, 0, 9, 9, true)
+		grid.Paste(10, 10, 19, 19)
+		grid.ToggleNodeMutes(0, 0, 19, 19)
+		grid.Resize(30, 30)
+		grid.RemoveNodes(0, 0, 29, 29)
+	})
+	<-done
+}
+
+// TestSignalMove checks that signals move at the rate and speed of their
+// emitter, and disappear at the end of their lifetime.
+func TestSignalMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     common.Rate
+		speed    common.Speed
+		lifetime int
+		want     int // Position of the signal, -1 when it disappeared.
+	}{
+		{name: "half rate", rate: common.HALF, want: 2},
+		{name: "normal", want: 4},
+		{name: "double rate", rate: common.DOUBLE, want: 8},
+		{name: "fast", speed: 1, want: 7},
+		{name: "slow", speed: -1, want: 2},
+		{name: "alive", lifetime: 4, want: 4},
+		{name: "expired", lifetime: 3, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &midi.Mock{}
+			grid := NewGrid(20, 1, m, "")
+			device := m.NewDevice("", "")
+			emitter := node.NewBangEmitter(m, &device, grid.Playback(), common.RIGHT, true)
+			emitter.SetRate(tt.rate)
+			emitter.SetSpeed(tt.speed)
+			emitter.SetLifetime(tt.lifetime)
+			grid.AddNode(emitter, 0, 0)
+			for range 4*common.PulsesPerStep + 1 {
+				grid.Update()
+			}
+			got := -1
+			for x := range grid.Width {
+				if _, ok := grid.Node(x, 0).(*node.Signal); ok {
+					got = x
+				}
+			}
+			if got != tt.want {
+				t.Errorf("signal at %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEdgeMode checks the behavior of signals reaching the grid edges.
+func TestEdgeMode(t *testing.T) {
+	tests := []struct {
+		edge EdgeMode
+		want int // Position of the signal, -1 when it disappeared.
+	}{
+		{edge: KILL, want: -1},
+		{edge: WRAP, want: 0},
+		{edge: BOUNCE, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.edge.Name(), func(t *testing.T) {
+			m := &midi.Mock{}
+			grid := NewGrid(4, 1, m, "")
+			grid.Edge = tt.edge
+			grid.AddNode(node.NewSignal(common.RIGHT, 0, common.NORMAL, 0, 0, common.Payload{}), 0, 0)
+			for range 4*common.PulsesPerStep + 1 {
+				grid.Update()
+			}
+			got := -1
+			for x := range grid.Width {
+				if _, ok := grid.Node(x, 0).(*node.Signal); ok {
+					got = x
+				}
+			}
+			if got != tt.want {
+				t.Errorf("signal at %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRouters checks that routers redirect signals.
+func TestRouters(t *testing.T) {
+	tests := []struct {
+		name   string
+		router common.Node
+		wantX  int // Position of the signal, -1 when it was blocked.
+		wantY  int
+	}{
+		{name: "mirror", router: node.NewMirror(false), wantX: 1, wantY: 1},
+		{name: "backslash mirror", router: node.NewMirror(true), wantX: 1, wantY: 3},
+		{name: "rotator", router: node.NewRotator(true), wantX: 1, wantY: 3},
+		{name: "counterclockwise rotator", router: node.NewRotator(false), wantX: 1, wantY: 1},
+		{name: "open valve", router: node.NewValve(common.RIGHT), wantX: 2, wantY: 2},
+		{name: "closed valve", router: node.NewValve(common.UP), wantX: -1, wantY: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewGrid(5, 5, &midi.Mock{}, "")
+			grid.AddNode(node.NewSignal(common.RIGHT, 0, common.NORMAL, 0, 0, common.Payload{}), 0, 2)
+			grid.AddNode(tt.router, 1, 2)
+			for range common.PulsesPerStep + 1 {
+				grid.Update()
+			}
+			gotX, gotY := -1, -1
+			for y := range grid.Height {
+				for x := range grid.Width {
+					if _, ok := grid.Node(x, y).(*node.Signal); ok {
+						gotX, gotY = x, y
+					}
+				}
+			}
+			if gotX != tt.wantX || gotY != tt.wantY {
+				t.Errorf("signal at %d,%d, want %d,%d", gotX, gotY, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+// testGrid is a single row grid recording the midi messages played by its
+// nodes.
+type testGrid struct {
+	*Grid
+	recorder *midi.Recorder
+	device   midi.Device
+}
+
+// playedNote is a note on or off played by a test grid.
+type playedNote struct {
+	on       bool
+	key      uint8
+	velocity uint8 // Velocity of note ons.
+	position uint64
+}
+
+// newTestGrid creates a test grid of the given width.
+func newTestGrid(width int) *testGrid {
+	recorder := midi.NewRecorder()
+	return &testGrid{
+		Grid:     NewGrid(width, 1, recorder, ""),
+		recorder: recorder,
+		device:   recorder.NewDevice("", ""),
+	}
+}
+
+// addBang adds a silent bang emitter on the first cell, triggering the nodes
+// on its right on the first step.
+func (g *testGrid) addBang() *node.Emitter {
+	bang := node.NewBangEmitter(g.recorder, &g.device, g.Playback(), common.RIGHT, true)
+	bang.Note().Key.SetSilent(true)
+	g.AddNode(bang, 0, 0)
+	return bang
+}
+
+// addEuclid adds a euclid emitter triggering its note on every step.
+func (g *testGrid) addEuclid(x int) *node.EuclidEmitter {
+	euclid := node.NewEuclidEmitter(g.recorder, &g.device, g.Playback(), common.NONE)
+	euclid.Steps.Set(1)
+	euclid.Triggers.Set(1)
+	g.AddNode(euclid, x, 0)
+	return euclid
+}
+
+// reload saves the grid in a bank and loads it back.
+func (g *testGrid) reload(t *testing.T) {
+	bank := filesystem.New(filepath.Join(t.TempDir(), "bank.json"))
+	g.Save(bank)
+	g.Load(0, bank.ActiveGrid())
+}
+
+// play updates the grid for the given number of pulses and returns the
+// notes played, positioned from the first pulse. The grid is updated for
+// the latency of the notes on top, notes being positioned on the pulse they
+// were triggered at, shifted by their nudge. Notes nudged early on the first
+// pulse are skipped.
+func (g *testGrid) play(pulses int) []playedNote {
+	start := len(g.recorder.Events())
+	for pulse := range pulses + music.Latency {
+		g.recorder.SetPosition(uint64(pulse))
+		g.Update()
+	}
+	notes := []playedNote{}
+	var channel, key, velocity uint8
+	for _, e := range g.recorder.Events()[start:] {
+		if e.Position < uint64(music.Latency) {
+			continue
+		}
+		position := e.Position - uint64(music.Latency)
+		if e.Message.GetNoteOn(&channel, &key, &velocity) {
+			notes = append(notes, playedNote{on: true, key: key, velocity: velocity, position: position})
+		} else if e.Message.GetNoteOff(&channel, &key, &velocity) {
+			notes = append(notes, playedNote{key: key, position: position})
+		}
+	}
+	return notes
+}
+
+// noteOns returns the note ons of the played notes.
+func noteOns(notes []playedNote) []playedNote {
+	return slices.DeleteFunc(notes, func(n playedNote) bool {
+		return !n.on
+	})
+}
+
+// TestPayload checks that emitters hit by a signal apply its payload.
+func TestPayload(t *testing.T) {
+	grid := newTestGrid(5)
+	grid.addBang().SetPayload(common.Payload{Transpose: 7, Velocity: -50})
+	grid.AddNode(node.NewSpreadEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE), 2, 0)
+
+	got := grid.play(2*common.PulsesPerStep + 1)
+	want := []playedNote{{on: true, key: 67, velocity: 50, position: 2 * uint64(common.PulsesPerStep)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestPayloadOrigin checks that emitters keep the origin and hop count of
+// the signal that last triggered them.
+func TestPayloadOrigin(t *testing.T) {
+	grid := newTestGrid(5)
+	bang := grid.addBang()
+	first := node.NewSpreadEmitter(grid.recorder, &grid.device, grid.Playback(), common.RIGHT)
+	second := node.NewSpreadEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE)
+	grid.AddNode(first, 2, 0)
+	grid.AddNode(second, 4, 0)
+
+	grid.play(6 * common.PulsesPerStep)
+	tests := []struct {
+		name   string
+		node   *node.Emitter
+		origin common.Node
+		hops   int
+	}{
+		{name: "first", node: first, origin: bang, hops: 1},
+		{name: "second", node: second, origin: first, hops: 2},
+	}
+	for _, tt := range tests {
+		if got := tt.node.Received(); got.Origin != tt.origin || got.Hops != tt.hops {
+			t.Errorf("%s emitter received from %v after %d hops, want %v after %d hops", tt.name, got.Origin, got.Hops, tt.origin, tt.hops)
+		}
+	}
+}
+
+// TestChord checks that chord emitters strum the chord built on their
+// note, following the grid root and scale.
+func TestChord(t *testing.T) {
+	grid := newTestGrid(3)
+	grid.addBang()
+	chord := node.NewChordEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE)
+	chord.Behavior().(*node.ChordEmitter).Chord().Strum.Set(2)
+	grid.AddNode(chord, 1, 0)
+	grid.SetScale(theory.IONIAN)
+	grid.SetKey(62)
+
+	got := noteOns(grid.play(2 * common.PulsesPerStep))
+	step := uint64(common.PulsesPerStep)
+	want := []playedNote{
+		{on: true, key: 62, velocity: 100, position: step},
+		{on: true, key: 66, velocity: 100, position: step + 2},
+		{on: true, key: 69, velocity: 100, position: step + 4},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestArpeggiator checks that arpeggiators play the keys of their chord one
+// step after the other, in the order of their pattern.
+func TestArpeggiator(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   node.ArpPattern
+		octaves   int
+		inversion int
+		want      []uint8
+	}{
+		{name: "up", pattern: node.ARP_UP, octaves: 1, want: []uint8{60, 64, 67}},
+		{name: "down", pattern: node.ARP_DOWN, octaves: 1, want: []uint8{67, 64, 60}},
+		{name: "up-down", pattern: node.ARP_UP_DOWN, octaves: 2, want: []uint8{60, 64, 67}},
+		{name: "played", pattern: node.ARP_PLAYED, octaves: 1, inversion: 1, want: []uint8{72, 64, 67}},
+		{name: "octaves", pattern: node.ARP_DOWN, octaves: 2, want: []uint8{79, 76, 72}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := newTestGrid(5)
+			grid.SetScale(theory.IONIAN)
+			grid.addBang()
+			arp := node.NewArpEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE)
+			arp.Pattern.Set(int(tt.pattern))
+			arp.Octaves.Set(tt.octaves)
+			arp.Chord().Inversion.Set(tt.inversion)
+			grid.AddNode(arp, 2, 0)
+
+			got := []uint8{}
+			for _, n := range noteOns(grid.play(4*common.PulsesPerStep + 1)) {
+				got = append(got, n.key)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("played keys %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestReproducible checks that a grid played again from its seed plays the
+// same notes, random walks included.
+func TestReproducible(t *testing.T) {
+	grid := newTestGrid(1)
+	euclid := grid.addEuclid(0)
+	euclid.Note().Probability = 70
+	euclid.Note().Key.SetRandomAmount(5)
+	euclid.Note().Key.SetDistribution(common.BIPOLAR)
+	euclid.Note().Velocity.SetRandomAmount(10)
+	euclid.Note().Velocity.SetDistribution(common.DRUNK)
+
+	takes := make([][]playedNote, 2)
+	for i := range takes {
+		grid.Reset()
+		grid.SetSeed(42)
+		takes[i] = grid.play(32 * common.PulsesPerStep)
+	}
+	if !slices.Equal(takes[0], takes[1]) {
+		t.Errorf("second take played %v, want %v", takes[1], takes[0])
+	}
+}
+
+// TestSequence checks that sequenced parameters survive a save and load,
+// and cycle through their values on each trigger.
+func TestSequence(t *testing.T) {
+	grid := newTestGrid(1)
+	euclid := grid.addEuclid(0)
+	euclid.Note().Key.SetSequence([]theory.Key{60, 63, 67}, common.FORWARD, grid.Key)
+	euclid.Note().Velocity.SetSequence([]uint8{100, 60}, common.PINGPONG)
+	grid.reload(t)
+
+	got := noteOns(grid.play(4 * common.PulsesPerStep))
+	want := []playedNote{
+		{on: true, key: 60, velocity: 100, position: 0},
+		{on: true, key: 63, velocity: 60, position: 6},
+		{on: true, key: 67, velocity: 100, position: 12},
+		{on: true, key: 60, velocity: 60, position: 18},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestFill checks that nodes flagged for fill only play and emit in fill
+// mode, held by notes on the fill channel.
+func TestFill(t *testing.T) {
+	for _, fill := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fill %t", fill), func(t *testing.T) {
+			grid := newTestGrid(5)
+			grid.FillChannel = 16
+			if fill {
+				grid.receive(gomidi.NoteOn(15, 36, 100))
+			}
+			if grid.Fill() != fill {
+				t.Fatalf("fill mode is %t, want %t", grid.Fill(), fill)
+			}
+			bang := grid.addBang()
+			bang.Note().Key.SetSilent(false)
+			bang.Note().Fill = music.InFillMode
+			grid.AddNode(node.NewSpreadEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE), 2, 0)
+
+			got := len(noteOns(grid.play(2*common.PulsesPerStep + 1)))
+			want := 0
+			if fill {
+				want = 2
+			}
+			if got != want {
+				t.Errorf("played %d notes, want %d", got, want)
+			}
+		})
+	}
+
+	grid := NewGrid(1, 1, &midi.Mock{}, "")
+	grid.FillChannel = 16
+	grid.receive(gomidi.NoteOn(15, 36, 100))
+	grid.receive(gomidi.NoteOff(15, 36))
+	if grid.Fill() {
+		t.Error("fill mode still on after the fill note was released")
+	}
+}
+
+// TestNudge checks that nudged notes are played late or early off the
+// step, and released after their length.
+func TestNudge(t *testing.T) {
+	grid := newTestGrid(3)
+	late := grid.addEuclid(0)
+	late.Note().SetKey(62, grid.Key)
+	late.Note().Nudge = 2
+	late.Note().SetLength(1)
+	early := grid.addEuclid(1)
+	early.Note().SetKey(64, grid.Key)
+	early.Note().Nudge = -2
+	early.Note().SetLength(1)
+	grid.addEuclid(2).Note().SetLength(1)
+
+	got := grid.play(2 * common.PulsesPerStep)
+	want := []playedNote{
+		{on: true, key: 60, velocity: 100, position: 0},
+		{key: 60, position: 1},
+		{on: true, key: 62, velocity: 100, position: 2},
+		{key: 62, position: 3},
+		{on: true, key: 64, velocity: 100, position: 4},
+		{key: 64, position: 5},
+		{on: true, key: 60, velocity: 100, position: 6},
+		{key: 60, position: 7},
+		{on: true, key: 62, velocity: 100, position: 8},
+		{key: 62, position: 9},
+		{on: true, key: 64, velocity: 100, position: 10},
+		{key: 64, position: 11},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestRatchet checks that ratchets repeat the note at their rate, with a
+// decaying velocity, each note lasting the note length.
+func TestRatchet(t *testing.T) {
+	grid := newTestGrid(1)
+	euclid := grid.addEuclid(0)
+	euclid.Note().Ratchet = music.Ratchet{Count: 3, Rate: 2, Decay: 50}
+	euclid.Note().SetLength(1)
+
+	got := grid.play(common.PulsesPerStep)
+	want := []playedNote{
+		{on: true, key: 60, velocity: 100, position: 0},
+		{key: 60, position: 1},
+		{on: true, key: 60, velocity: 50, position: 2},
+		{key: 60, position: 3},
+		{on: true, key: 60, velocity: 25, position: 4},
+		{key: 60, position: 5},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestLegato checks that legato notes survive a save and load, and are
+// released after the next note starts, a same key being released just
+// before it.
+func TestLegato(t *testing.T) {
+	grid := newTestGrid(1)
+	euclid := grid.addEuclid(0)
+	euclid.Note().Key.SetSequence([]theory.Key{60, 60, 63}, common.FORWARD, grid.Key)
+	euclid.Note().SetLength(music.BarLength)
+	euclid.Note().Legato = true
+	grid.reload(t)
+
+	got := grid.play(3 * common.PulsesPerStep)
+	want := []playedNote{
+		{on: true, key: 60, velocity: 100, position: 0},
+		{key: 60, position: 6},
+		{on: true, key: 60, velocity: 100, position: 6},
+		{on: true, key: 63, velocity: 100, position: 12},
+		{key: 60, position: 12},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
+
+// TestLoadLength checks that lengths of 127 pulses are only loaded as
+// infinite lengths from legacy grids.
+func TestLoadLength(t *testing.T) {
+	grid := NewGrid(1, 1, &midi.Mock{}, "")
+	grid.AddNodeFromSymbol("b", 0, 0)
+	grid.Node(0, 0).(music.Audible).Note().SetLength(127)
+	bank := filesystem.New(filepath.Join(t.TempDir(), "bank.json"))
+	grid.Save(bank)
+
+	saved := bank.ActiveGrid()
+	legacy := saved
+	legacy.Version = filesystem.LegacyGridVersion
+	for _, tt := range []struct {
+		grid filesystem.Grid
+		want int
+	}{{saved, 127}, {legacy, music.InfiniteLength}} {
+		grid.Load(0, tt.grid)
+		if got := grid.Node(0, 0).(music.Audible).Note().Length.Value(); got != tt.want {
+			t.Errorf("length 127 of a version %d grid loaded as %d, want %d", tt.grid.Version, got, tt.want)
+		}
+	}
+}
+
+// TestLoadArp checks that the arpeggiator values are saved with their
+// sequences and random amounts.
+func TestLoadArp(t *testing.T) {
+	grid := newTestGrid(1)
+	arp := node.NewArpEmitter(grid.recorder, &grid.device, grid.Playback(), common.NONE)
+	arp.Octaves.SetSequence([]int{1, 3}, common.FORWARD)
+	arp.Gate.SetRandomAmount(10)
+	arp.Chord().Inversion.SetSequence([]int{0, 2}, common.FORWARD)
+	grid.AddNode(arp, 0, 0)
+
+	grid.reload(t)
+	loaded := grid.Node(0, 0).(*node.ArpEmitter)
+	if got := loaded.Octaves.Sequence().Values(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("octaves sequence loaded as %v, want [1 3]", got)
+	}
+	if got := loaded.Gate.RandomAmount(); got != 10 {
+		t.Errorf("gate random amount loaded as %d, want 10", got)
+	}
+	if got := loaded.Chord().Inversion.Sequence().Values(); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("inversion sequence loaded as %v, want [0 2]", got)
+	}
+}
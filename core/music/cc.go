@@ -82,21 +82,22 @@ func (c *CC) SetType(t int) {
 	}
 }
 
-func (c CC) Send(device int, channel uint8) {
+// Send sends the control with a value computed from the given random source.
+func (c CC) Send(rand *common.Random, device int, channel uint8) {
 	switch c.Type {
 	case SilentControlType:
 		return
 	case ControlChangeControlType:
-		c.midi.ControlChange(device, channel, c.Controller, c.Value.Computed())
+		c.midi.ControlChange(device, channel, c.Controller, c.Value.Computed(rand))
 	case AfterTouchControlType:
-		c.midi.AfterTouch(device, channel, c.Value.Computed())
+		c.midi.AfterTouch(device, channel, c.Value.Computed(rand))
 	case ProgramChangeControlType:
-		c.midi.ProgramChange(device, channel, c.Value.Computed())
+		c.midi.ProgramChange(device, channel, c.Value.Computed(rand))
 	case PitchBendControlType:
 		value := 0
-		if c.Value.Computed() != defaultPitchBendValue {
+		if computed := c.Value.Computed(rand); computed != defaultPitchBendValue {
 			value = remap(
-				int(c.Value.Computed()),
+				int(computed),
 				int(minControlValue),
 				int(maxControlValue),
 				int(minPitchBendValue),
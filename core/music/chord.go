@@ -0,0 +1,67 @@
+package music
+
+import (
+	"signls/core/common"
+	"signls/core/theory"
+)
+
+const (
+	defaultChord = theory.TRIAD
+
+	// MaxStrum is the maximum delay between two chord keys, in pulses.
+	MaxStrum = 4 * common.PulsesPerStep
+
+	// maxChord is the chord stacking all the degrees a chord can stack.
+	maxChord = 1<<(theory.MaxChordDegree+1) - 1
+)
+
+// Chord holds the settings of a chord played by a note. Its values are
+// computed on each trigger, like the note values.
+type Chord struct {
+	Type      *common.ControlValue[int] // Degrees of the chord, as a theory.Chord.
+	Voicing   *common.ControlValue[int]
+	Inversion *common.ControlValue[int]
+	Strum     *common.ControlValue[int] // Delay between two successive chord keys, in pulses.
+}
+
+// NewChord creates a new chord with default settings.
+func NewChord() *Chord {
+	return &Chord{
+		Type:      common.NewControlValue(int(defaultChord), 1, maxChord),
+		Voicing:   common.NewControlValue(int(theory.CLOSE), int(theory.CLOSE), int(theory.MaxVoicing)),
+		Inversion: common.NewControlValue(0, 0, theory.MaxChordDegree),
+		Strum:     common.NewControlValue(0, 0, MaxStrum),
+	}
+}
+
+// Copy returns a copy of the chord.
+func (c *Chord) Copy() *Chord {
+	newType := *c.Type
+	newVoicing := *c.Voicing
+	newInversion := *c.Inversion
+	newStrum := *c.Strum
+	return &Chord{
+		Type:      &newType,
+		Voicing:   &newVoicing,
+		Inversion: &newInversion,
+		Strum:     &newStrum,
+	}
+}
+
+// Keys computes the chord values and returns the voiced keys of the chord
+// built on the given key, following the root and scale of the grid, in the
+// chord stacking order.
+func (c *Chord) Keys(rand *common.Random, key theory.Key, root theory.Key, scale theory.Scale) []theory.Key {
+	chord := theory.Chord(c.Type.Computed(rand))
+	voicing := theory.Voicing(c.Voicing.Computed(rand))
+	c.Strum.Computed(rand)
+	return voicing.Voice(chord.Keys(key, root, scale), c.Inversion.Computed(rand))
+}
+
+// Rewind restarts the value sequences and the random walks of the chord.
+func (c *Chord) Rewind() {
+	c.Type.Rewind()
+	c.Voicing.Rewind()
+	c.Inversion.Rewind()
+	c.Strum.Rewind()
+}
@@ -0,0 +1,161 @@
+package music
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	minConditionCycle = 2
+	maxConditionCycle = 8
+)
+
+type ConditionType int
+
+const (
+	AlwaysCondition ConditionType = iota
+	RatioCondition
+	FirstCondition
+	NotFirstCondition
+	FillCondition
+	NotFillCondition
+	PreviousCondition
+	NotPreviousCondition
+)
+
+var (
+	conditionNames = map[ConditionType]string{
+		AlwaysCondition:      "-",
+		FirstCondition:       "1st",
+		NotFirstCondition:    "!1st",
+		FillCondition:        "fill",
+		NotFillCondition:     "!fill",
+		PreviousCondition:    "pre",
+		NotPreviousCondition: "!pre",
+	}
+
+	conditionHelps = map[ConditionType]string{
+		AlwaysCondition:      "always plays",
+		FirstCondition:       "plays on the first trigger only",
+		NotFirstCondition:    "plays on all triggers but the first",
+		FillCondition:        "plays in fill mode only",
+		NotFillCondition:     "plays out of fill mode only",
+		PreviousCondition:    "plays when the previous condition was true",
+		NotPreviousCondition: "plays when the previous condition was false",
+	}
+)
+
+// Condition is a trig condition, it decides whether a triggered note plays
+// depending on the previous triggers.
+type Condition struct {
+	Type ConditionType
+	A, B int // Ratio conditions play on the A-th of every B triggers.
+
+	count int // Number of triggers since the last rewind.
+}
+
+// NewCondition returns a condition that always plays.
+func NewCondition() Condition {
+	return Condition{Type: AlwaysCondition}
+}
+
+// NewRatioCondition returns a condition playing on the a-th of every b
+// triggers.
+func NewRatioCondition(a, b int) Condition {
+	return Condition{Type: RatioCondition, A: a, B: b}
+}
+
+// AllConditions returns all the trig conditions, ratio conditions last.
+func AllConditions() []Condition {
+	conditions := []Condition{
+		NewCondition(),
+		{Type: FirstCondition},
+		{Type: NotFirstCondition},
+		{Type: FillCondition},
+		{Type: NotFillCondition},
+		{Type: PreviousCondition},
+		{Type: NotPreviousCondition},
+	}
+	for b := minConditionCycle; b <= maxConditionCycle; b++ {
+		for a := 1; a <= b; a++ {
+			conditions = append(conditions, NewRatioCondition(a, b))
+		}
+	}
+	return conditions
+}
+
+// ParseCondition parses a condition from its name, ex: "3:4" or "!fill".
+func ParseCondition(input string) (Condition, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	for _, c := range AllConditions() {
+		if c.Name() == input {
+			return c, nil
+		}
+	}
+	var a, b int
+	if _, err := fmt.Sscanf(input, "%d:%d", &a, &b); err != nil {
+		return Condition{}, errors.New("unknown condition")
+	}
+	if b < minConditionCycle || b > maxConditionCycle || a < 1 || a > b {
+		return Condition{}, fmt.Errorf("condition %d:%d out of range", a, b)
+	}
+	return NewRatioCondition(a, b), nil
+}
+
+// Name returns the short name of the condition.
+func (c Condition) Name() string {
+	if c.Type == RatioCondition {
+		return fmt.Sprintf("%d:%d", c.A, c.B)
+	}
+	return conditionNames[c.Type]
+}
+
+// Help returns a description of the condition.
+func (c Condition) Help() string {
+	if c.Type == RatioCondition {
+		return fmt.Sprintf("plays on trigger %d of every %d", c.A, c.B)
+	}
+	return conditionHelps[c.Type]
+}
+
+// Equal returns true when both conditions are the same, regardless of
+// their trigger count.
+func (c Condition) Equal(other Condition) bool {
+	return c.Type == other.Type && c.A == other.A && c.B == other.B
+}
+
+// Evaluate counts a trigger and returns whether the note plays in the
+// playback state of the grid. Conditions other than the previous ones store
+// their result in it, for the next previous conditions.
+func (c *Condition) Evaluate(playback *Playback) bool {
+	count := c.count
+	c.count++
+
+	var result bool
+	switch c.Type {
+	case RatioCondition:
+		result = c.B > 0 && count%c.B == c.A-1
+	case FirstCondition:
+		result = count == 0
+	case NotFirstCondition:
+		result = count > 0
+	case FillCondition:
+		result = playback.Fill
+	case NotFillCondition:
+		result = !playback.Fill
+	case PreviousCondition:
+		return playback.previous
+	case NotPreviousCondition:
+		return !playback.previous
+	default:
+		return true
+	}
+	playback.previous = result
+	return result
+}
+
+// Rewind restarts the trigger count.
+func (c *Condition) Rewind() {
+	c.count = 0
+}
@@ -0,0 +1,83 @@
+package music
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConditionEvaluate(t *testing.T) {
+	tests := []struct {
+		condition Condition
+		fill      bool
+		want      []bool
+	}{
+		{condition: NewCondition(), want: []bool{true, true, true}},
+		{condition: NewRatioCondition(1, 2), want: []bool{true, false, true, false}},
+		{condition: NewRatioCondition(3, 4), want: []bool{false, false, true, false, false, false, true}},
+		{condition: Condition{Type: FirstCondition}, want: []bool{true, false, false}},
+		{condition: Condition{Type: NotFirstCondition}, want: []bool{false, true, true}},
+		{condition: Condition{Type: FillCondition}, want: []bool{false, false}},
+		{condition: Condition{Type: FillCondition}, fill: true, want: []bool{true, true}},
+		{condition: Condition{Type: NotFillCondition}, fill: true, want: []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition.Name(), func(t *testing.T) {
+			playback := NewPlayback(nil)
+			playback.Fill = tt.fill
+			got := make([]bool, len(tt.want))
+			for i := range got {
+				got[i] = tt.condition.Evaluate(playback)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionPrevious(t *testing.T) {
+	ratio := NewRatioCondition(1, 2)
+	pre := Condition{Type: PreviousCondition}
+	notPre := Condition{Type: NotPreviousCondition}
+	playback := NewPlayback(nil)
+	for i := range 4 {
+		played := ratio.Evaluate(playback)
+		if pre.Evaluate(playback) != played || notPre.Evaluate(playback) == played {
+			t.Errorf("trigger %d: previous conditions don't follow the ratio condition", i)
+		}
+	}
+
+	ratio.Evaluate(playback)
+	playback.Reset()
+	if pre.Evaluate(playback) {
+		t.Error("previous condition still true after a reset")
+	}
+	if other := NewPlayback(nil); !notPre.Evaluate(other) {
+		t.Error("previous condition shared between playbacks")
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Condition
+		wantErr bool
+	}{
+		{input: "3:4", want: NewRatioCondition(3, 4)},
+		{input: "!fill", want: Condition{Type: NotFillCondition}},
+		{input: "1st", want: Condition{Type: FirstCondition}},
+		{input: "5:4", wantErr: true},
+		{input: "1:9", wantErr: true},
+		{input: "foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCondition(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCondition(%q) error = %v, want error %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("ParseCondition(%q) = %s, want %s", tt.input, got.Name(), tt.want.Name())
+		}
+	}
+}
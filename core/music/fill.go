@@ -0,0 +1,33 @@
+package music
+
+type FillMode int
+
+const (
+	AnyFillMode FillMode = iota
+	InFillMode
+	OutOfFillMode
+)
+
+var fillModeNames = map[FillMode]string{
+	AnyFillMode:   "-",
+	InFillMode:    "fill",
+	OutOfFillMode: "!fill",
+}
+
+// Name returns the short name of the fill mode.
+func (m FillMode) Name() string {
+	return fillModeNames[m]
+}
+
+// Active returns true when a node with this fill mode can play and emit in
+// the given fill state.
+func (m FillMode) Active(fill bool) bool {
+	switch m {
+	case InFillMode:
+		return fill
+	case OutOfFillMode:
+		return !fill
+	default:
+		return true
+	}
+}
@@ -0,0 +1,61 @@
+package music
+
+import (
+	"fmt"
+
+	"signls/core/theory"
+)
+
+const (
+	anyInputChannel uint8 = 0
+	maxInputChannel uint8 = 16
+)
+
+// InputTrigger is a filter for notes received from a midi input device.
+// Matching notes arm and trigger the note emitter.
+type InputTrigger struct {
+	Active   bool
+	Key      theory.Key
+	Channel  uint8 // 0 matches any channel, 1-16 otherwise.
+	Velocity bool  // Incoming velocity overrides the note velocity.
+}
+
+// NewInputTrigger returns a new inactive input trigger.
+func NewInputTrigger() InputTrigger {
+	return InputTrigger{
+		Key:     defaultKey,
+		Channel: anyInputChannel,
+	}
+}
+
+// Match checks if an incoming note matches the filter.
+func (t InputTrigger) Match(channel uint8, key uint8) bool {
+	if !t.Active || theory.Key(key) != t.Key {
+		return false
+	}
+	return t.Channel == anyInputChannel || t.Channel == channel+1
+}
+
+// SetKey updates the key to match.
+func (t *InputTrigger) SetKey(key theory.Key) {
+	if key < minKey || key > maxKey {
+		return
+	}
+	t.Key = key
+}
+
+// SetChannel updates the channel to match.
+func (t *InputTrigger) SetChannel(channel uint8) {
+	if channel > maxInputChannel {
+		return
+	}
+	t.Channel = channel
+}
+
+// DisplayChannel returns the string representation of the channel filter.
+func (t InputTrigger) DisplayChannel() string {
+	if t.Channel == anyInputChannel {
+		return "any"
+	}
+	return fmt.Sprintf("%d", t.Channel)
+}
@@ -11,6 +11,11 @@ type Audible interface {
 	Note() *Note
 	Muted() bool
 	SetMute(mute bool)
-	Trig(key theory.Key, scale theory.Scale, inDir common.Direction, pulse uint64)
+	Trig(key theory.Key, scale theory.Scale, inDir common.Direction, payload common.Payload, pulse uint64)
 	Emit(pulse uint64) []common.Direction
 }
+
+// Chorded represents an interface for nodes and behaviors playing chords.
+type Chorded interface {
+	Chord() *Chord
+}
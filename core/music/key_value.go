@@ -1,10 +1,7 @@
 package music
 
 import (
-	"math"
-	"math/rand"
-	"time"
-
+	"signls/core/common"
 	"signls/core/theory"
 	"signls/midi"
 )
@@ -15,22 +12,22 @@ const (
 )
 
 type KeyValue struct {
-	rand *rand.Rand
-
 	key      theory.Key
 	nextKey  theory.Key
 	lastKey  theory.Key
 	interval int
 	amount   int
+	sequence common.Sequence[int] // Intervals from the root, played instead of the key.
+
+	distribution common.Distribution
+	walk         int // Offset of the last key, drunk walks step from it.
 
 	silent bool
 }
 
 func NewKeyValue(key theory.Key) *KeyValue {
-	source := rand.NewSource(time.Now().UnixNano())
 	return &KeyValue{
-		key:  key,
-		rand: rand.New(source),
+		key: key,
 	}
 }
 
@@ -53,23 +50,31 @@ func (p *KeyValue) Display() string {
 	return midi.Note(uint8(p.Value()))
 }
 
-func (p *KeyValue) Computed(root theory.Key, scale theory.Scale) theory.Key {
+func (p *KeyValue) Computed(rand *common.Random, root theory.Key, scale theory.Scale) theory.Key {
 	if p.nextKey > 0 {
 		p.key = p.nextKey
 		p.nextKey = 0
 	}
+	base := p.key
+	if p.sequence.Len() > 0 {
+		base = sequenceKey(root, scale, p.sequence.Next(rand))
+	}
 	if p.amount == 0 {
-		p.lastKey = p.key
+		p.lastKey = base
 		return p.lastKey
 	}
-	key := theory.Key(p.rand.Intn(int(math.Abs(float64(p.amount))) + 1))
-	if p.amount > 0 {
-		key = p.key + key
-	} else {
-		key = p.key - key
-	}
+	offset := p.distribution.Offset(rand, p.amount, p.walk)
+	key := theory.Key(min(max(int(base)+offset, int(minKey)), int(maxKey)))
 	interval := key.AllSemitonesFrom(root)
 	p.lastKey = p.key.Transpose(root, scale, interval)
+	p.walk = int(key) - int(base)
+	return p.lastKey
+}
+
+// Shift transposes the last computed key by a number of semitones, so that
+// the shifted note is the one stopped afterwards.
+func (p *KeyValue) Shift(semitones int) theory.Key {
+	p.lastKey = theory.Key(min(max(int(p.lastKey)+semitones, 0), int(maxKey)))
 	return p.lastKey
 }
 
@@ -103,6 +108,55 @@ func (p *KeyValue) SetRandomAmount(amount int) {
 	p.amount = amount
 }
 
+// Distribution returns the distribution of the random amount.
+func (p *KeyValue) Distribution() common.Distribution {
+	return p.distribution
+}
+
+// SetDistribution changes the distribution of the random amount.
+func (p *KeyValue) SetDistribution(distribution common.Distribution) {
+	if distribution < common.UNIFORM || distribution > common.MaxDistribution {
+		return
+	}
+	p.distribution = distribution
+	p.walk = 0
+}
+
+// Sequence returns the sequence of intervals from the root played instead
+// of the key, empty when not in sequence mode.
+func (p *KeyValue) Sequence() *common.Sequence[int] {
+	return &p.sequence
+}
+
+// SetSequence sets a sequence of keys played instead of the key. Keys are
+// stored as intervals from the root, so that they follow root and scale
+// changes. An empty sequence leaves the sequence mode.
+func (p *KeyValue) SetSequence(keys []theory.Key, mode common.SequenceMode, root theory.Key) {
+	intervals := []int{}
+	for _, k := range keys {
+		if k < minKey || k > maxKey {
+			continue
+		}
+		intervals = append(intervals, k.AllSemitonesFrom(root))
+	}
+	p.sequence.Set(intervals, mode)
+}
+
+// Rewind restarts the sequence and the random walk.
+func (p *KeyValue) Rewind() {
+	p.sequence.Rewind()
+	p.walk = 0
+}
+
+// SequenceKeys returns the keys of the sequence for a given root.
+func (p *KeyValue) SequenceKeys(root theory.Key) []theory.Key {
+	keys := make([]theory.Key, p.sequence.Len())
+	for i, interval := range p.sequence.Values() {
+		keys[i] = theory.Key(min(max(int(root)+interval, int(minKey)), int(maxKey)))
+	}
+	return keys
+}
+
 func (p *KeyValue) IsSilent() bool {
 	return p.silent
 }
@@ -110,3 +164,10 @@ func (p *KeyValue) IsSilent() bool {
 func (p *KeyValue) SetSilent(silent bool) {
 	p.silent = silent
 }
+
+// sequenceKey returns the key at an interval from the root, moved to the
+// closest key in scale.
+func sequenceKey(root theory.Key, scale theory.Scale, interval int) theory.Key {
+	key := theory.Key(min(max(int(root)+interval, int(minKey)), int(maxKey)))
+	return key.Transpose(root, scale, key.AllSemitonesFrom(root))
+}
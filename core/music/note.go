@@ -2,8 +2,7 @@ package music
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
+	"slices"
 
 	"signls/core/common"
 	"signls/core/music/meta"
@@ -16,42 +15,56 @@ const (
 	defaultKey      theory.Key = 60 // Middle C
 	defaultChannel  uint8      = 0
 	defaultVelocity uint8      = 100
-	defaultLength   uint8      = uint8(common.PulsesPerStep)
+	defaultLength   int        = common.PulsesPerStep
 
 	defaultCCNumbers int = 8
 
 	maxVelocity    uint8 = 127
-	maxLength      uint8 = 127 // 127 is treated as infinity
-	minLength      uint8 = 1
 	maxChannel     uint8 = 15
 	maxProbability uint8 = 100
 )
 
+// Constants defining the note lengths, in pulses.
+const (
+	MinLength      = 1
+	BarLength      = 4 * common.StepsPerQuarterNote * common.PulsesPerStep
+	MaxLength      = 16 * BarLength
+	InfiniteLength = MaxLength + 1 // Notes lasting until the next trigger.
+)
+
 var lastUsedChannel uint8 = defaultChannel
 
 // Note represents a midi note.
 type Note struct {
 	midi midi.Midi
 
-	rand *rand.Rand
-
 	Device      *DeviceValue
 	Key         *KeyValue
 	Channel     *common.ControlValue[uint8]
 	Velocity    *common.ControlValue[uint8]
-	Length      *common.ControlValue[uint8]
+	Length      *common.ControlValue[int]
+	Legato      bool // Overlaps retriggered notes instead of stopping them first.
 	Probability uint8
+	Condition   Condition
+	Fill        FillMode // Restricts the note and its emitter to a fill state.
+	Nudge       int      // Offset of the note from the step, in pulses.
+	Ratchet     Ratchet
 
 	Controls     []*CC
 	MetaCommands []meta.Command
 
-	pulse     uint64 // Internal pulse counter to manage note length.
-	triggered bool
+	Input InputTrigger
+
+	playback *Playback // State of the grid playing the note.
+
+	inputVelocity uint8     // Velocity received from a midi input, used on next play.
+	velocity      uint8     // Velocity computed on the last trigger.
+	sounding      []noteKey // Keys played and not released yet.
 }
 
-// NewNote initializes a new Note with default settings and the provided MIDI interface.
-func NewNote(midi midi.Midi, device *midi.Device) *Note {
-	source := rand.NewSource(time.Now().UnixNano())
+// NewNote initializes a new Note with default settings, the provided MIDI
+// interface and the playback state of its grid.
+func NewNote(midi midi.Midi, device *midi.Device, playback *Playback) *Note {
 	ccs := make([]*CC, defaultCCNumbers)
 	for i := range ccs {
 		ccs[i] = NewCC(midi, SilentControlType)
@@ -67,15 +80,18 @@ func NewNote(midi midi.Midi, device *midi.Device) *Note {
 	}
 	return &Note{
 		midi:         midi,
-		rand:         rand.New(source),
+		playback:     playback,
 		Device:       &deviceValue,
 		Key:          NewKeyValue(defaultKey),
 		Channel:      common.NewControlValue[uint8](lastUsedChannel, 0, maxChannel),
 		Velocity:     common.NewControlValue[uint8](defaultVelocity, 0, maxVelocity),
-		Length:       common.NewControlValue[uint8](defaultLength, minLength, maxLength),
+		Length:       common.NewControlValue[int](defaultLength, MinLength, InfiniteLength),
 		Probability:  maxProbability,
+		Condition:    NewCondition(),
+		Ratchet:      NewRatchet(),
 		Controls:     ccs,
 		MetaCommands: cmds,
+		Input:        NewInputTrigger(),
 	}
 }
 
@@ -86,7 +102,6 @@ func (n Note) Copy() *Note {
 	newChannel := *n.Channel
 	newVelocity := *n.Velocity
 	newLength := *n.Length
-	source := rand.NewSource(time.Now().UnixNano())
 	newControls := make([]*CC, defaultCCNumbers)
 	for i, c := range n.Controls {
 		newControls[i] = c.Copy()
@@ -97,62 +112,193 @@ func (n Note) Copy() *Note {
 	}
 	return &Note{
 		midi:         n.midi,
-		rand:         rand.New(source),
+		playback:     n.playback,
 		Device:       &newDevice,
 		Key:          &newKey,
 		Channel:      &newChannel,
 		Velocity:     &newVelocity,
 		Length:       &newLength,
+		Legato:       n.Legato,
 		Probability:  n.Probability,
+		Condition:    n.Condition,
+		Fill:         n.Fill,
+		Nudge:        n.Nudge,
+		Ratchet:      n.Ratchet,
 		Controls:     newControls,
 		MetaCommands: newCmds,
+		Input:        n.Input,
 	}
 }
 
-// Tick advances the internal pulse counter and stops the note if it exceeds its length.
-func (n *Note) Tick() {
-	if !n.triggered {
-		return
+// TransposeAndPlay triggers the note with a specific root and scale, resetting internal state.
+// The payload of the triggering signal shifts the played key and velocity.
+func (n *Note) TransposeAndPlay(root theory.Key, scale theory.Scale, payload common.Payload) {
+	n.play(root, scale, payload, nil)
+}
+
+// TransposeAndPlayChord triggers the note like TransposeAndPlay, but plays
+// the given chord built on the note key instead of the key alone.
+func (n *Note) TransposeAndPlayChord(root theory.Key, scale theory.Scale, payload common.Payload, chord *Chord) {
+	n.play(root, scale, payload, chord)
+}
+
+// TransposeAndArpeggiate triggers the note like TransposeAndPlayChord
+// without playing anything, and returns the chord keys to be played one by
+// one with PlayKey. It returns false when the note must not be played.
+func (n *Note) TransposeAndArpeggiate(root theory.Key, scale theory.Scale, payload common.Payload, chord *Chord) ([]theory.Key, bool) {
+	key, ok := n.trigger(root, scale, payload)
+	if !ok {
+		return nil, false
 	}
-	n.pulse++
+	n.sendControls()
+	return chord.Keys(n.playback.Rand, key, root, scale), true
+}
+
+// PlayKey plays a key instead of the note key for the given length in
+// pulses, with the velocity computed on the last trigger.
+func (n *Note) PlayKey(key theory.Key, length int) {
+	held := n.retrigger()
+	n.schedule([]theory.Key{key}, 0, length, held)
+}
 
-	// Stop the note if its duration is complete.
-	if n.Length.Last() < maxLength && n.pulse >= uint64(n.Length.Last()) {
-		n.Stop()
+func (n *Note) play(root theory.Key, scale theory.Scale, payload common.Payload, chord *Chord) {
+	key, ok := n.trigger(root, scale, payload)
+	if !ok {
+		return
+	}
+	held := n.retrigger()
+	n.sendControls()
+	if chord != nil {
+		keys := chord.Keys(n.playback.Rand, key, root, scale)
+		slices.Sort(keys)
+		n.schedule(keys, chord.Strum.Last(), n.Length.Last(), held)
+		return
 	}
+	n.schedule([]theory.Key{key}, 0, n.Length.Last(), held)
 }
 
-// TransposeAndPlay triggers the note with a specific root and scale, resetting internal state.
-func (n *Note) TransposeAndPlay(root theory.Key, scale theory.Scale) {
+// trigger computes the note values for a specific root and scale, and
+// returns the key to play, shifted by the payload of the triggering signal.
+// It returns false when the note is silent, out of its fill state or skipped
+// by its condition or its probability.
+func (n *Note) trigger(root theory.Key, scale theory.Scale, payload common.Payload) (theory.Key, bool) {
+	inputVelocity := n.inputVelocity
+	n.inputVelocity = 0
+
 	if n.Key.IsSilent() {
-		return
+		return 0, false
+	}
+
+	if !n.Fill.Active(n.playback.Fill) || !n.Condition.Evaluate(n.playback) {
+		return 0, false
 	}
 
 	if n.Probability < maxProbability &&
-		uint8(rand.Int31n((100))) >= n.Probability {
-		return
+		uint8(n.playback.Rand.Intn(100)) >= n.Probability {
+		return 0, false
 	}
 
 	n.Transpose(root, scale)
-	n.Stop()
-	n.midi.NoteOn(
-		n.Device.Get(),
-		n.Channel.Computed(),
-		uint8(n.Key.Computed(root, scale)),
-		n.Velocity.Computed(),
-	)
-	n.Length.Computed() // Just trigger length computation
+	velocity := n.Velocity.Computed(n.playback.Rand)
+	if inputVelocity > 0 {
+		velocity = inputVelocity
+	}
+	n.velocity = payload.ScaleVelocity(velocity)
+	n.Key.Computed(n.playback.Rand, root, scale)
+	n.Channel.Computed(n.playback.Rand)
+	n.Length.Computed(n.playback.Rand)
+	return n.Key.Shift(payload.Transpose), true
+}
 
+// retrigger stops the playing note before a new one, from the pulse the new
+// one starts at: the events of the playing note due before it are kept, the
+// later ones are dropped. The keys still sounding then are released when
+// the new one starts, or in legato mode, returned to be released on the
+// first key of the new one.
+func (n *Note) retrigger() []noteKey {
+	start := min(n.Nudge, 0)
+	held := n.playback.retrigger(n, start)
+	if n.Legato {
+		return held
+	}
+	for _, k := range held {
+		n.playback.schedule(start, event{noteKey: k, note: n})
+	}
+	return nil
+}
+
+// sendControls sends the note control changes and executes its meta commands.
+func (n *Note) sendControls() {
 	for _, control := range n.Controls {
-		control.Send(n.Device.Get(), n.Channel.Last())
+		control.Send(n.playback.Rand, n.Device.Get(), n.Channel.Last())
 	}
 
 	for _, cmd := range n.MetaCommands {
 		cmd.Execute()
 	}
+}
+
+// schedule schedules the keys of a trigger, after the nudge of the note.
+// Each ratchet plays the keys one every strum pulses, and releases them at
+// the end of the length or at the start of the next ratchet, keys due after
+// it being skipped. Infinite notes are released on the next trigger only.
+// Held keys are released on the first key, a held key matching it being
+// released just before it so that it never overlaps itself.
+func (n *Note) schedule(keys []theory.Key, strum, length int, held []noteKey) {
+	device, channel := n.Device.Get(), n.Channel.Last()
+	for r := range n.Ratchet.Last() + 1 {
+		start := n.Nudge + n.Ratchet.Offset(r)
+		end := start + length
+		infinite := length >= InfiniteLength && r == n.Ratchet.Last()
+		if r < n.Ratchet.Last() {
+			end = min(end, n.Nudge+n.Ratchet.Offset(r+1))
+		}
+		played := []noteKey{}
+		for i, key := range keys {
+			delay := start + i*strum
+			if delay >= end && !infinite {
+				break
+			}
+			k := noteKey{device, channel, key}
+			if j := slices.Index(held, k); j >= 0 {
+				n.playback.schedule(delay, event{noteKey: k, note: n})
+				held = slices.Delete(held, j, j+1)
+			}
+			n.playback.schedule(delay, event{
+				noteKey:  k,
+				note:     n,
+				on:       true,
+				velocity: n.Ratchet.Velocity(n.velocity, r),
+			})
+			for _, h := range held {
+				n.playback.schedule(delay, event{noteKey: h, note: n})
+			}
+			held = nil
+			played = append(played, k)
+		}
+		if infinite {
+			continue
+		}
+		for _, k := range played {
+			n.playback.schedule(end, event{noteKey: k, note: n})
+		}
+	}
+	for _, h := range held {
+		n.playback.schedule(n.Nudge, event{noteKey: h, note: n})
+	}
+}
 
-	n.triggered = true
-	n.pulse = 0
+// send sends a scheduled event and keeps track of the sounding keys.
+func (n *Note) send(e event) {
+	if !e.on {
+		n.midi.NoteOff(e.device, e.channel, uint8(e.key))
+		if i := slices.Index(n.sounding, e.noteKey); i >= 0 {
+			n.sounding = slices.Delete(n.sounding, i, i+1)
+		}
+		return
+	}
+	n.midi.NoteOn(e.device, e.channel, uint8(e.key), e.velocity)
+	n.sounding = append(n.sounding, e.noteKey)
 }
 
 // Play just triggers the note. Used for note preview.
@@ -161,30 +307,55 @@ func (n *Note) Play() {
 		return
 	}
 
-	n.Stop()
 	n.midi.NoteOn(
 		n.Device.Get(),
 		n.Channel.Value(),
 		uint8(n.Key.Value()),
 		n.Velocity.Value(),
 	)
+}
 
-	n.triggered = true
-	n.pulse = 0
+// SetInputVelocity overrides the velocity of the next played note with a
+// velocity received from a midi input.
+func (n *Note) SetInputVelocity(velocity uint8) {
+	n.inputVelocity = velocity
 }
 
 // Silence silences the note channel
 func (n *Note) Silence() {
 	n.midi.Silence(n.Device.Get(), n.Channel.Value())
-	n.triggered = false
-	n.pulse = 0
+	n.sounding = nil
 }
 
-// Stop sends a MIDI Note Off message and resets the triggered state.
+// Stop drops the pending events of the note and sends note offs for its
+// sounding keys.
 func (n *Note) Stop() {
-	n.midi.NoteOff(n.Device.Get(), n.Channel.Last(), uint8(n.Key.Last()))
-	n.triggered = false
-	n.pulse = 0
+	n.playback.cancel(n)
+	for _, k := range n.sounding {
+		n.midi.NoteOff(k.device, k.channel, uint8(k.key))
+	}
+	n.sounding = nil
+}
+
+// playing returns true when the note is sounding or about to.
+func (n *Note) playing() bool {
+	return len(n.sounding) > 0 || n.playback.pending(n)
+}
+
+// Rewind restarts the value sequences, the random walks and the trig
+// condition of the note.
+func (n *Note) Rewind() {
+	n.Condition.Rewind()
+	n.Key.Rewind()
+	n.Channel.Rewind()
+	n.Velocity.Rewind()
+	n.Length.Rewind()
+	for _, control := range n.Controls {
+		control.Value.Rewind()
+	}
+	for _, cmd := range n.MetaCommands {
+		cmd.Value().Rewind()
+	}
 }
 
 // Transpose transposes current key for a given root and scale.
@@ -195,7 +366,7 @@ func (n *Note) Transpose(root theory.Key, scale theory.Scale) {
 // SetKey sets the next key to play.
 func (n *Note) SetKey(key theory.Key, root theory.Key) {
 	n.Key.SetNext(key, root)
-	if !n.triggered {
+	if !n.playing() {
 		n.Key.Set(n.Key.Value())
 	}
 }
@@ -206,7 +377,7 @@ func (n *Note) SetVelocity(velocity uint8) {
 }
 
 // SetLength updates the length of the note.
-func (n *Note) SetLength(length uint8) {
+func (n *Note) SetLength(length int) {
 	n.Length.Set(length)
 }
 
@@ -256,3 +427,8 @@ func (n *Note) Symbol() string {
 func (n *Note) Midi() midi.Midi {
 	return n.midi
 }
+
+// Playback returns the playback state of the grid playing the note.
+func (n *Note) Playback() *Playback {
+	return n.playback
+}
@@ -0,0 +1,14 @@
+package music
+
+import "signls/core/common"
+
+// Nudge limits, in pulses.
+const (
+	MinNudge = -(common.PulsesPerStep - 1)
+	MaxNudge = common.PulsesPerStep - 1
+)
+
+// Latency is the number of pulses every note is delayed by once triggered,
+// so that notes nudged early can still be played ahead of the other notes
+// of their step.
+const Latency = -MinNudge
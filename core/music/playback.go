@@ -0,0 +1,122 @@
+package music
+
+import (
+	"slices"
+
+	"signls/core/common"
+	"signls/core/theory"
+)
+
+// Playback holds the state of a grid shared by all its nodes while it plays.
+// Each grid has its own, so that grids played side by side (ex: a render
+// while playing live) never affect each other.
+//
+// It also queues the note events scheduled by the notes, sent by the grid
+// once their pulse is played. Notes schedule all the events of a trigger at
+// once (nudged, strummed and ratcheted keys and their releases), and cancel
+// them when retriggered or stopped. The queue runs Latency pulses ahead of
+// the played notes, so that notes nudged early are played before their step.
+type Playback struct {
+	Rand  *common.Random // Random source of the grid.
+	Fill  bool           // Fill mode state of the grid during the current pulse.
+	Pulse uint64         // Pulse being played, events are scheduled from it.
+
+	previous bool    // Result of the last evaluated condition, used by the previous conditions.
+	events   []event // Events waiting for their pulse, in scheduling order.
+}
+
+// noteKey is a key played on a device and channel.
+type noteKey struct {
+	device  int
+	channel uint8
+	key     theory.Key
+}
+
+// event is a note on or off scheduled by a note.
+type event struct {
+	noteKey
+	pulse    uint64
+	note     *Note
+	on       bool
+	velocity uint8
+}
+
+// NewPlayback creates the playback state of a grid drawing from a random
+// source.
+func NewPlayback(rand *common.Random) *Playback {
+	return &Playback{
+		Rand: rand,
+	}
+}
+
+// Flush sends the events due at the pulse being played, in the order they
+// were scheduled.
+func (p *Playback) Flush() {
+	pending := p.events[:0]
+	for _, e := range p.events {
+		if e.pulse > p.Pulse {
+			pending = append(pending, e)
+			continue
+		}
+		e.note.send(e)
+	}
+	clear(p.events[len(pending):])
+	p.events = pending
+}
+
+// Reset clears the state left by the previous playback, dropping the
+// pending events.
+func (p *Playback) Reset() {
+	p.previous = false
+	p.Pulse = 0
+	p.events = nil
+}
+
+// schedule queues an event played the given number of pulses after the
+// pulse being played, on top of the latency. Delays down to MinNudge are
+// played before the pulse of the other notes.
+func (p *Playback) schedule(delay int, e event) {
+	e.pulse = p.Pulse + uint64(Latency+delay)
+	p.events = append(p.events, e)
+}
+
+// cancel drops the pending events of a note.
+func (p *Playback) cancel(n *Note) {
+	p.events = slices.DeleteFunc(p.events, func(e event) bool {
+		return e.note == n
+	})
+}
+
+// retrigger drops the events of a note due from the given number of pulses
+// after the pulse being played, on top of the latency, and returns the keys
+// of the note sounding at that pulse.
+func (p *Playback) retrigger(n *Note, delay int) []noteKey {
+	pulse := p.Pulse + uint64(Latency+delay)
+	sounding := slices.Clone(n.sounding)
+	kept := p.events[:0]
+	for _, e := range p.events {
+		if e.note != n {
+			kept = append(kept, e)
+			continue
+		}
+		if e.pulse >= pulse {
+			continue
+		}
+		kept = append(kept, e)
+		if e.on {
+			sounding = append(sounding, e.noteKey)
+		} else if i := slices.Index(sounding, e.noteKey); i >= 0 {
+			sounding = slices.Delete(sounding, i, i+1)
+		}
+	}
+	clear(p.events[len(kept):])
+	p.events = kept
+	return sounding
+}
+
+// pending returns true when a note has events waiting for their pulse.
+func (p *Playback) pending(n *Note) bool {
+	return slices.ContainsFunc(p.events, func(e event) bool {
+		return e.note == n
+	})
+}
@@ -0,0 +1,55 @@
+package music
+
+import (
+	"slices"
+	"testing"
+
+	"signls/core/common"
+	"signls/core/theory"
+	"signls/midi"
+)
+
+// TestPlaybackStop checks that stopping a note drops its pending events and
+// releases its sounding keys right away.
+func TestPlaybackStop(t *testing.T) {
+	recorder := midi.NewRecorder()
+	device := recorder.NewDevice("", "")
+	playback := NewPlayback(common.NewRandom(1))
+	note := NewNote(recorder, &device, playback)
+	note.velocity = 100
+	note.Nudge = 2
+
+	note.schedule([]theory.Key{60}, 0, 3, nil)
+	for pulse := range Latency + 8 {
+		playback.Pulse = uint64(pulse)
+		recorder.SetPosition(uint64(pulse))
+		switch pulse {
+		case Latency + 3:
+			note.Stop()
+		case Latency + 4:
+			note.schedule([]theory.Key{62}, 0, 3, nil)
+		case Latency + 5:
+			note.Stop()
+		}
+		playback.Flush()
+	}
+
+	type played struct {
+		on       bool
+		key      uint8
+		position uint64
+	}
+	want := []played{{on: true, key: 60, position: uint64(Latency + 2)}, {key: 60, position: uint64(Latency + 3)}}
+	got := []played{}
+	var channel, key, velocity uint8
+	for _, e := range recorder.Events() {
+		if e.Message.GetNoteOn(&channel, &key, &velocity) {
+			got = append(got, played{on: true, key: key, position: e.Position})
+		} else if e.Message.GetNoteOff(&channel, &key, &velocity) {
+			got = append(got, played{key: key, position: e.Position})
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("played %v, want %v", got, want)
+	}
+}
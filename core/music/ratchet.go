@@ -0,0 +1,55 @@
+package music
+
+import (
+	"math"
+
+	"signls/core/common"
+)
+
+// Ratchet limits. A step can't hold more notes than pulses.
+const (
+	MaxRatchets     = common.PulsesPerStep
+	MaxRatchetRate  = 4 * common.PulsesPerStep
+	MaxRatchetDecay = 100
+)
+
+// Ratchet repeats a triggered note, for rolls and retriggers.
+type Ratchet struct {
+	Count int // Number of notes played on each trigger, 1 for a single note.
+	Rate  int // Pulses between two notes, 0 to spread them over a step.
+	Decay int // Velocity decrease of each note from the previous one, in percent.
+}
+
+// NewRatchet returns a ratchet playing a single note.
+func NewRatchet() Ratchet {
+	return Ratchet{Count: 1}
+}
+
+// Last returns the index of the last note of the ratchet.
+func (r Ratchet) Last() int {
+	return max(r.Count, 1) - 1
+}
+
+// Offset returns the number of pulses between the first note of the ratchet
+// and the note at the given index. Notes spread over a step are rounded to
+// the closest pulse, as evenly as the pulses of a step allow.
+func (r Ratchet) Offset(index int) int {
+	if r.Rate > 0 {
+		return index * r.Rate
+	}
+	return int(math.Round(float64(index*common.PulsesPerStep) / float64(max(r.Count, 1))))
+}
+
+// Velocity returns the velocity of a note of the ratchet, decayed from the
+// velocity of the first one. A decayed velocity never gets to 0, which would
+// stop the note.
+func (r Ratchet) Velocity(velocity uint8, index int) uint8 {
+	if velocity == 0 {
+		return velocity
+	}
+	v := int(velocity)
+	for range index {
+		v = v * (MaxRatchetDecay - r.Decay) / MaxRatchetDecay
+	}
+	return uint8(max(v, 1))
+}
@@ -0,0 +1,50 @@
+package music
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRatchetOffset(t *testing.T) {
+	tests := []struct {
+		ratchet Ratchet
+		want    []int
+	}{
+		{ratchet: NewRatchet(), want: []int{0}},
+		{ratchet: Ratchet{Count: 2}, want: []int{0, 3}},
+		{ratchet: Ratchet{Count: 3}, want: []int{0, 2, 4}},
+		{ratchet: Ratchet{Count: 4}, want: []int{0, 2, 3, 5}},
+		{ratchet: Ratchet{Count: 5}, want: []int{0, 1, 2, 4, 5}},
+		{ratchet: Ratchet{Count: MaxRatchets}, want: []int{0, 1, 2, 3, 4, 5}},
+		{ratchet: Ratchet{Count: 3, Rate: 12}, want: []int{0, 12, 24}},
+	}
+	for _, tt := range tests {
+		got := make([]int, tt.ratchet.Last()+1)
+		for i := range got {
+			got[i] = tt.ratchet.Offset(i)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("offsets of %+v are %v, want %v", tt.ratchet, got, tt.want)
+		}
+	}
+}
+
+func TestRatchetVelocity(t *testing.T) {
+	tests := []struct {
+		ratchet  Ratchet
+		velocity uint8
+		index    int
+		want     uint8
+	}{
+		{ratchet: Ratchet{Count: 3, Decay: 50}, velocity: 100, index: 0, want: 100},
+		{ratchet: Ratchet{Count: 3, Decay: 50}, velocity: 100, index: 2, want: 25},
+		{ratchet: Ratchet{Count: 6, Decay: 90}, velocity: 10, index: 5, want: 1},
+		{ratchet: Ratchet{Count: 2, Decay: MaxRatchetDecay}, velocity: 100, index: 1, want: 1},
+		{ratchet: Ratchet{Count: 2, Decay: 50}, velocity: 0, index: 1, want: 0},
+	}
+	for _, tt := range tests {
+		if got := tt.ratchet.Velocity(tt.velocity, tt.index); got != tt.want {
+			t.Errorf("velocity %d of note %d of %+v is %d, want %d", tt.velocity, tt.index, tt.ratchet, got, tt.want)
+		}
+	}
+}
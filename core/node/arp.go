@@ -0,0 +1,323 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+
+	"signls/core/common"
+	"signls/core/music"
+	"signls/core/theory"
+	"signls/midi"
+)
+
+// ArpPattern constants represent the orders in which arpeggiators play
+// their chord keys.
+const (
+	ARP_UP ArpPattern = iota
+	ARP_DOWN
+	ARP_UP_DOWN
+	ARP_RANDOM
+	ARP_PLAYED
+
+	MaxArpPattern = ARP_PLAYED
+)
+
+const (
+	defaultArpOctaves = 1
+	MaxArpOctaves     = 4
+
+	defaultArpGate = 50
+	MinArpGate     = 1
+	MaxArpGate     = 100
+)
+
+var arpPatternNames = map[ArpPattern]string{
+	ARP_UP:      "up",
+	ARP_DOWN:    "down",
+	ARP_UP_DOWN: "up-down",
+	ARP_RANDOM:  "random",
+	ARP_PLAYED:  "played",
+}
+
+// ArpPattern represents the order in which an arpeggiator plays its keys.
+type ArpPattern int
+
+// Name returns the name of the pattern.
+func (p ArpPattern) Name() string {
+	return arpPatternNames[p]
+}
+
+type ArpEmitter struct {
+	direction common.Direction
+	note      *music.Note
+	chord     *music.Chord
+	payload   common.Payload
+	incoming  common.Payload
+	rate      common.Rate
+	speed     common.Speed
+	lifetime  int
+
+	Pattern  *common.ControlValue[int] // Order of the keys, as an ArpPattern.
+	Octaves  *common.ControlValue[int]
+	Gate     *common.ControlValue[int] // Length of the arpeggio keys, in percent of a step.
+	EmitEach bool                      // Emits signals on each key instead of the first one only.
+
+	keys []theory.Key
+	step int
+
+	pulse     uint64
+	ticks     uint64
+	armed     bool
+	triggered bool
+	retrig    bool
+	muted     bool
+}
+
+func NewArpEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *ArpEmitter {
+	return &ArpEmitter{
+		direction: direction,
+		note:      music.NewNote(midi, device, playback),
+		chord:     music.NewChord(),
+		Pattern:   common.NewControlValue(int(ARP_UP), int(ARP_UP), int(MaxArpPattern)),
+		Octaves:   common.NewControlValue(defaultArpOctaves, 1, MaxArpOctaves),
+		Gate:      common.NewControlValue(defaultArpGate, MinArpGate, MaxArpGate),
+	}
+}
+
+func (e *ArpEmitter) Copy(dx, dy int) common.Node {
+	newPattern := *e.Pattern
+	newOctaves := *e.Octaves
+	newGate := *e.Gate
+	return &ArpEmitter{
+		direction: e.direction,
+		armed:     e.armed,
+		note:      e.note.Copy(),
+		chord:     e.chord.Copy(),
+		rate:      e.rate,
+		speed:     e.speed,
+		lifetime:  e.lifetime,
+		payload:   e.payload,
+		muted:     e.muted,
+		Pattern:   &newPattern,
+		Octaves:   &newOctaves,
+		Gate:      &newGate,
+		EmitEach:  e.EmitEach,
+	}
+}
+
+func (e *ArpEmitter) Activated() bool {
+	return e.armed || e.triggered
+}
+
+func (e *ArpEmitter) Note() *music.Note {
+	return e.note
+}
+
+func (e *ArpEmitter) Chord() *music.Chord {
+	return e.chord
+}
+
+func (e *ArpEmitter) Rate() common.Rate {
+	return e.rate
+}
+
+func (e *ArpEmitter) SetRate(rate common.Rate) {
+	e.rate = rate
+}
+
+func (e *ArpEmitter) Speed() common.Speed {
+	return e.speed
+}
+
+func (e *ArpEmitter) SetSpeed(speed common.Speed) {
+	e.speed = speed
+}
+
+func (e *ArpEmitter) Lifetime() int {
+	return e.lifetime
+}
+
+func (e *ArpEmitter) SetLifetime(lifetime int) {
+	e.lifetime = lifetime
+}
+
+func (e *ArpEmitter) Payload() common.Payload {
+	return common.Payload{
+		Transpose: e.payload.Transpose,
+		Velocity:  e.payload.Velocity,
+		Origin:    e,
+		Hops:      e.incoming.Hops + 1,
+	}
+}
+
+func (e *ArpEmitter) SetPayload(payload common.Payload) {
+	e.payload.Transpose = payload.Transpose
+	e.payload.Velocity = payload.Velocity
+}
+
+func (e *ArpEmitter) Received() common.Payload {
+	return e.incoming
+}
+
+func (e *ArpEmitter) Arm() {
+	e.armed = true
+}
+
+func (e *ArpEmitter) SetMute(mute bool) {
+	e.note.Stop()
+	e.keys = nil
+	e.muted = mute
+}
+
+func (e *ArpEmitter) Muted() bool {
+	return e.muted
+}
+
+func (e *ArpEmitter) Trig(key theory.Key, scale theory.Scale, inDir common.Direction, payload common.Payload, pulse uint64) {
+	if !e.armed {
+		return
+	}
+	e.keys = nil
+	e.step = 0
+	if !e.muted {
+		keys, ok := e.note.TransposeAndArpeggiate(key, scale, payload, e.chord)
+		if ok {
+			e.Pattern.Computed(e.note.Playback().Rand)
+			e.Octaves.Computed(e.note.Playback().Rand)
+			e.Gate.Computed(e.note.Playback().Rand)
+			e.keys = e.arpeggio(keys)
+		}
+		if len(e.keys) > 0 {
+			e.playStep()
+		}
+	}
+	if e.triggered {
+		e.retrig = true
+	} else {
+		e.pulse = pulse
+	}
+	e.incoming = payload
+	e.triggered = true
+	e.armed = false
+}
+
+func (e *ArpEmitter) Emit(pulse uint64) []common.Direction {
+	if e.updated(pulse) || !e.triggered {
+		return []common.Direction{}
+	}
+	if e.retrig {
+		e.retrig = false
+	} else {
+		e.triggered = false
+	}
+	e.pulse = pulse
+	return e.direction.Decompose()
+}
+
+func (e *ArpEmitter) Tick() {
+	e.arpTrigger()
+	e.ticks++
+}
+
+// arpTrigger plays the next key of the arpeggio on each step of the
+// emitter rate.
+func (e *ArpEmitter) arpTrigger() {
+	if e.step == 0 || e.step >= len(e.keys) {
+		return
+	}
+	if e.ticks%uint64(e.rate.Pulses()) != 0 {
+		return
+	}
+	e.playStep()
+	if !e.EmitEach {
+		return
+	}
+	if e.triggered {
+		e.retrig = true
+	} else {
+		e.triggered = true
+	}
+}
+
+func (e *ArpEmitter) playStep() {
+	key := e.keys[e.step]
+	if ArpPattern(e.Pattern.Last()) == ARP_RANDOM {
+		key = e.keys[e.note.Playback().Rand.Intn(len(e.keys))]
+	}
+	gate := max(e.Gate.Last()*e.rate.Pulses()/MaxArpGate, 1)
+	e.note.PlayKey(key, gate)
+	e.step++
+}
+
+// arpeggio returns the keys of the chord spread over the octave range, in
+// the order of the pattern.
+func (e *ArpEmitter) arpeggio(chord []theory.Key) []theory.Key {
+	keys := []theory.Key{}
+	for octave := range e.Octaves.Last() {
+		for _, k := range chord {
+			if int(k)+12*octave > 127 {
+				continue
+			}
+			keys = append(keys, k+theory.Key(12*octave))
+		}
+	}
+
+	switch ArpPattern(e.Pattern.Last()) {
+	case ARP_UP, ARP_RANDOM:
+		slices.Sort(keys)
+	case ARP_DOWN:
+		slices.Sort(keys)
+		slices.Reverse(keys)
+	case ARP_UP_DOWN:
+		slices.Sort(keys)
+		for i := len(keys) - 2; i > 0; i-- {
+			keys = append(keys, keys[i])
+		}
+	}
+	return keys
+}
+
+func (e *ArpEmitter) Direction() common.Direction {
+	return e.direction
+}
+
+func (e *ArpEmitter) SetDirection(dir common.Direction) {
+	if e.direction.Contains(dir) {
+		e.direction = e.direction.Remove(dir)
+		return
+	}
+	e.direction = e.direction.Add(dir)
+}
+
+func (e *ArpEmitter) Symbol() string {
+	return fmt.Sprintf("%s%s%s", "A", e.note.Symbol(), e.direction.Symbol())
+}
+
+func (e *ArpEmitter) Name() string {
+	return "arp"
+}
+
+func (e *ArpEmitter) Color() string {
+	return "214"
+}
+
+func (e *ArpEmitter) Reset() {
+	e.pulse = 0
+	e.ticks = 0
+	e.triggered = false
+	e.armed = false
+	e.retrig = false
+	e.keys = nil
+	e.step = 0
+	e.incoming = common.Payload{}
+	e.Pattern.Rewind()
+	e.Octaves.Rewind()
+	e.Gate.Rewind()
+	e.chord.Rewind()
+	e.Note().Stop()
+	e.Note().Rewind()
+}
+
+func (e *ArpEmitter) updated(pulse uint64) bool {
+	return e.pulse == pulse
+}
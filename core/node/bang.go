@@ -8,11 +8,11 @@ import (
 
 type BangEmitter struct{}
 
-func NewBangEmitter(midi midi.Midi, device *midi.Device, direction common.Direction, armed bool) *Emitter {
+func NewBangEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction, armed bool) *Emitter {
 	return &Emitter{
 		direction: direction,
 		armed:     armed,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 		behavior:  &BangEmitter{},
 	}
 }
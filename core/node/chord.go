@@ -0,0 +1,59 @@
+package node
+
+import (
+	"signls/core/common"
+	"signls/core/music"
+	"signls/midi"
+)
+
+type ChordEmitter struct {
+	chord *music.Chord
+}
+
+func NewChordEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *Emitter {
+	return &Emitter{
+		direction: direction,
+		note:      music.NewNote(midi, device, playback),
+		behavior: &ChordEmitter{
+			chord: music.NewChord(),
+		},
+	}
+}
+
+func (e *ChordEmitter) EmitDirections(dir common.Direction, inDir common.Direction, pulse uint64) common.Direction {
+	return dir
+}
+
+func (e *ChordEmitter) ShouldPropagate() bool {
+	return false
+}
+
+func (e *ChordEmitter) ArmedOnStart() bool {
+	return false
+}
+
+func (e *ChordEmitter) Copy() common.EmitterBehavior {
+	return &ChordEmitter{
+		chord: e.chord.Copy(),
+	}
+}
+
+func (e *ChordEmitter) Chord() *music.Chord {
+	return e.chord
+}
+
+func (e *ChordEmitter) Symbol() string {
+	return "K"
+}
+
+func (e *ChordEmitter) Name() string {
+	return "chord"
+}
+
+func (e *ChordEmitter) Color() string {
+	return "166"
+}
+
+func (e *ChordEmitter) Reset() {
+	e.chord.Rewind()
+}
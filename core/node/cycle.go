@@ -10,16 +10,18 @@ import (
 
 type CycleEmitter struct {
 	repeat *common.ControlValue[int]
+	rand   *common.Random
 	count  int
 	next   int
 }
 
-func NewCycleEmitter(midi midi.Midi, device *midi.Device, direction common.Direction) *Emitter {
+func NewCycleEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *Emitter {
 	return &Emitter{
 		direction: direction,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 		behavior: &CycleEmitter{
 			repeat: common.NewControlValue[int](0, 0, math.MaxInt32),
+			rand:   playback.Rand,
 		},
 	}
 }
@@ -33,7 +35,7 @@ func (e *CycleEmitter) EmitDirections(dir common.Direction, inDir common.Directi
 		e.count++
 		return dir.Decompose()[d]
 	}
-	e.Repeat().Computed()
+	e.Repeat().Computed(e.rand)
 	e.count = 0
 	e.next = (e.next + 1) % dir.Count()
 	return dir.Decompose()[d]
@@ -56,6 +58,7 @@ func (e *CycleEmitter) Copy() common.EmitterBehavior {
 	return &CycleEmitter{
 		next:   e.next,
 		repeat: &newRepeat,
+		rand:   e.rand,
 	}
 }
 
@@ -73,4 +76,6 @@ func (e *CycleEmitter) Color() string {
 
 func (e *CycleEmitter) Reset() {
 	e.next = 0
+	e.count = 0
+	e.repeat.Rewind()
 }
@@ -2,8 +2,6 @@ package node
 
 import (
 	"math"
-	"math/rand"
-	"time"
 
 	"signls/core/common"
 	"signls/core/music"
@@ -11,20 +9,19 @@ import (
 )
 
 type DiceEmitter struct {
-	rand   *rand.Rand
 	repeat *common.ControlValue[int]
+	rand   *common.Random
 	last   int
 	count  int
 }
 
-func NewDiceEmitter(midi midi.Midi, device *midi.Device, direction common.Direction) *Emitter {
-	source := rand.NewSource(time.Now().UnixNano())
+func NewDiceEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *Emitter {
 	return &Emitter{
 		direction: direction,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 		behavior: &DiceEmitter{
-			rand:   rand.New(source),
 			repeat: common.NewControlValue[int](0, 0, math.MaxInt32),
+			rand:   playback.Rand,
 		},
 	}
 }
@@ -37,7 +34,7 @@ func (e *DiceEmitter) EmitDirections(dir common.Direction, inDir common.Directio
 		e.count++
 		return dir.Decompose()[e.last]
 	}
-	e.repeat.Computed()
+	e.repeat.Computed(e.rand)
 	e.count = 0
 	e.last = e.rand.Intn(dir.Count())
 	return dir.Decompose()[e.last]
@@ -56,11 +53,10 @@ func (e *DiceEmitter) ArmedOnStart() bool {
 }
 
 func (e *DiceEmitter) Copy() common.EmitterBehavior {
-	source := rand.NewSource(time.Now().UnixNano())
 	newRepeat := *e.repeat
 	return &DiceEmitter{
-		rand:   rand.New(source),
 		repeat: &newRepeat,
+		rand:   e.rand,
 	}
 }
 
@@ -76,4 +72,8 @@ func (e *DiceEmitter) Color() string {
 	return "33"
 }
 
-func (e *DiceEmitter) Reset() {}
+func (e *DiceEmitter) Reset() {
+	e.last = 0
+	e.count = 0
+	e.repeat.Rewind()
+}
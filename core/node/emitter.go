@@ -14,7 +14,12 @@ type Emitter struct {
 
 	direction         common.Direction
 	incomingDirection common.Direction
+	incomingPayload   common.Payload
+	payload           common.Payload
 	note              *music.Note
+	rate              common.Rate
+	speed             common.Speed
+	lifetime          int
 
 	pulse     uint64
 	armed     bool
@@ -30,6 +35,10 @@ func (e *Emitter) Copy(dx, dy int) common.Node {
 		direction: e.direction,
 		armed:     e.armed,
 		note:      newNote,
+		rate:      e.rate,
+		speed:     e.speed,
+		lifetime:  e.lifetime,
+		payload:   e.payload,
 		muted:     e.muted,
 	}
 }
@@ -46,6 +55,48 @@ func (e *Emitter) Arm() {
 	e.armed = true
 }
 
+func (e *Emitter) Rate() common.Rate {
+	return e.rate
+}
+
+func (e *Emitter) SetRate(rate common.Rate) {
+	e.rate = rate
+}
+
+func (e *Emitter) Speed() common.Speed {
+	return e.speed
+}
+
+func (e *Emitter) SetSpeed(speed common.Speed) {
+	e.speed = speed
+}
+
+func (e *Emitter) Lifetime() int {
+	return e.lifetime
+}
+
+func (e *Emitter) SetLifetime(lifetime int) {
+	e.lifetime = lifetime
+}
+
+func (e *Emitter) Payload() common.Payload {
+	return common.Payload{
+		Transpose: e.payload.Transpose,
+		Velocity:  e.payload.Velocity,
+		Origin:    e,
+		Hops:      e.incomingPayload.Hops + 1,
+	}
+}
+
+func (e *Emitter) SetPayload(payload common.Payload) {
+	e.payload.Transpose = payload.Transpose
+	e.payload.Velocity = payload.Velocity
+}
+
+func (e *Emitter) Received() common.Payload {
+	return e.incomingPayload
+}
+
 func (e *Emitter) Behavior() common.EmitterBehavior {
 	return e.behavior
 }
@@ -63,15 +114,14 @@ func (e *Emitter) Muted() bool {
 	return e.muted
 }
 
-func (e *Emitter) Trig(key theory.Key, scale theory.Scale, inDir common.Direction, pulse uint64) {
-	if !e.updated(pulse) {
-		e.note.Tick()
-	}
+func (e *Emitter) Trig(key theory.Key, scale theory.Scale, inDir common.Direction, payload common.Payload, pulse uint64) {
 	if !e.armed {
 		return
 	}
-	if !e.muted {
-		e.note.TransposeAndPlay(key, scale)
+	if c, ok := e.behavior.(*ChordEmitter); ok && !e.muted {
+		e.note.TransposeAndPlayChord(key, scale, payload, c.Chord())
+	} else if !e.muted {
+		e.note.TransposeAndPlay(key, scale, payload)
 	}
 	if !e.updated(pulse) && e.triggered {
 		e.retrig = true
@@ -79,6 +129,7 @@ func (e *Emitter) Trig(key theory.Key, scale theory.Scale, inDir common.Directio
 		e.pulse = pulse
 	}
 	e.incomingDirection = inDir
+	e.incomingPayload = payload
 	e.triggered = true
 	e.armed = false
 }
@@ -96,9 +147,7 @@ func (e *Emitter) Emit(pulse uint64) []common.Direction {
 	return e.behavior.EmitDirections(e.direction, e.incomingDirection, pulse).Decompose()
 }
 
-func (e *Emitter) Tick() {
-	e.note.Tick()
-}
+func (e *Emitter) Tick() {}
 
 func (e *Emitter) Direction() common.Direction {
 	return e.direction
@@ -138,7 +187,9 @@ func (e *Emitter) Reset() {
 	e.pulse = 0
 	e.armed = e.behavior.ArmedOnStart()
 	e.triggered = false
+	e.incomingPayload = common.Payload{}
 	e.Note().Stop()
+	e.Note().Rewind()
 	e.behavior.Reset()
 }
 
@@ -21,6 +21,11 @@ const (
 type EuclidEmitter struct {
 	direction common.Direction
 	note      *music.Note
+	payload   common.Payload
+	incoming  common.Payload
+	rate      common.Rate
+	speed     common.Speed
+	lifetime  int
 
 	Steps    *common.ControlValue[int]
 	Triggers *common.ControlValue[int]
@@ -36,14 +41,14 @@ type EuclidEmitter struct {
 	muted     bool
 }
 
-func NewEuclidEmitter(midi midi.Midi, device *midi.Device, direction common.Direction) *EuclidEmitter {
+func NewEuclidEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *EuclidEmitter {
 	return &EuclidEmitter{
 		Steps:     common.NewControlValue[int](defaultSteps, minSteps, maxSteps),
 		Triggers:  common.NewControlValue[int](defaultTriggers, minSteps, maxSteps),
 		Offset:    common.NewControlValue[int](defaultOffset, defaultOffset, maxSteps),
 		direction: direction,
 		armed:     true,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 	}
 }
 
@@ -56,6 +61,10 @@ func (e *EuclidEmitter) Copy(dx, dy int) common.Node {
 		direction: e.direction,
 		armed:     e.armed,
 		note:      newNote,
+		rate:      e.rate,
+		speed:     e.speed,
+		lifetime:  e.lifetime,
+		payload:   e.payload,
 		muted:     e.muted,
 		Steps:     &newSteps,
 		Triggers:  &newTriggers,
@@ -71,6 +80,48 @@ func (e *EuclidEmitter) Note() *music.Note {
 	return e.note
 }
 
+func (e *EuclidEmitter) Rate() common.Rate {
+	return e.rate
+}
+
+func (e *EuclidEmitter) SetRate(rate common.Rate) {
+	e.rate = rate
+}
+
+func (e *EuclidEmitter) Speed() common.Speed {
+	return e.speed
+}
+
+func (e *EuclidEmitter) SetSpeed(speed common.Speed) {
+	e.speed = speed
+}
+
+func (e *EuclidEmitter) Lifetime() int {
+	return e.lifetime
+}
+
+func (e *EuclidEmitter) SetLifetime(lifetime int) {
+	e.lifetime = lifetime
+}
+
+func (e *EuclidEmitter) Payload() common.Payload {
+	return common.Payload{
+		Transpose: e.payload.Transpose,
+		Velocity:  e.payload.Velocity,
+		Origin:    e,
+		Hops:      e.incoming.Hops + 1,
+	}
+}
+
+func (e *EuclidEmitter) SetPayload(payload common.Payload) {
+	e.payload.Transpose = payload.Transpose
+	e.payload.Velocity = payload.Velocity
+}
+
+func (e *EuclidEmitter) Received() common.Payload {
+	return e.incoming
+}
+
 func (e *EuclidEmitter) Arm() {
 	e.armed = true
 }
@@ -84,18 +135,19 @@ func (e *EuclidEmitter) Muted() bool {
 	return e.muted
 }
 
-func (e *EuclidEmitter) Trig(key theory.Key, scale theory.Scale, inDir common.Direction, pulse uint64) {
+func (e *EuclidEmitter) Trig(key theory.Key, scale theory.Scale, inDir common.Direction, payload common.Payload, pulse uint64) {
 	if !e.armed {
 		return
 	}
 	if !e.muted {
-		e.note.TransposeAndPlay(key, scale)
+		e.note.TransposeAndPlay(key, scale, payload)
 	}
 	if e.triggered {
 		e.retrig = true
 	} else {
 		e.pulse = pulse
 	}
+	e.incoming = payload
 	e.triggered = true
 	e.armed = false
 }
@@ -115,21 +167,21 @@ func (e *EuclidEmitter) Emit(pulse uint64) []common.Direction {
 
 func (e *EuclidEmitter) Tick() {
 	e.patternTrigger()
-	e.note.Tick()
 	e.ticks++
 }
 
 func (e *EuclidEmitter) patternTrigger() {
-	if e.ticks%uint64(common.PulsesPerStep) != 0 {
+	pulses := uint64(e.rate.Pulses())
+	if e.ticks%pulses != 0 {
 		return
 	}
 
-	if e.ticks%uint64(common.PulsesPerStep*e.Steps.Value()) == 0 {
-		newSteps := e.Steps.Computed()
+	if e.ticks%(pulses*uint64(e.Steps.Value())) == 0 {
+		newSteps := e.Steps.Computed(e.note.Playback().Rand)
 		e.Triggers.SetMax(newSteps)
 		e.Offset.SetMax(newSteps)
-		e.Triggers.Computed()
-		e.Offset.Computed()
+		e.Triggers.Computed(e.note.Playback().Rand)
+		e.Offset.Computed(e.note.Playback().Rand)
 	}
 
 	pattern := generateEuclideanPattern(e.Steps.Last(), e.Triggers.Last())
@@ -187,7 +239,12 @@ func (e *EuclidEmitter) Reset() {
 	e.armed = true
 	e.retrig = false
 	e.step = 0
+	e.incoming = common.Payload{}
+	e.Steps.Rewind()
+	e.Triggers.Rewind()
+	e.Offset.Rewind()
 	e.Note().Stop()
+	e.Note().Rewind()
 }
 
 func (e *EuclidEmitter) updated(pulse uint64) bool {
@@ -48,9 +48,9 @@ func (s *HoleEmitter) Direction() common.Direction {
 
 func (s *HoleEmitter) SetDirection(dir common.Direction) {}
 
-func (e *HoleEmitter) Teleport() (int, int) {
+func (e *HoleEmitter) Teleport(rand *common.Random) (int, int) {
 	e.activated = common.PulsesPerStep + 1
-	return e.DestinationX.Computed(), e.DestinationY.Computed()
+	return e.DestinationX.Computed(rand), e.DestinationY.Computed(rand)
 }
 
 func (e *HoleEmitter) Destination() (int, int) {
@@ -80,6 +80,8 @@ func (e *HoleEmitter) Tick() {
 
 func (e *HoleEmitter) Reset() {
 	e.activated = 0
+	e.DestinationX.Rewind()
+	e.DestinationY.Rewind()
 }
 
 func (s *HoleEmitter) Symbol() string {
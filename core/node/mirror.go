@@ -0,0 +1,70 @@
+package node
+
+import (
+	"signls/core/common"
+)
+
+// Mirror reflects signals at a right angle without triggering anything,
+// like a `/` or a `\` mirror.
+type Mirror struct {
+	activated int
+	Backslash bool
+}
+
+func NewMirror(backslash bool) *Mirror {
+	return &Mirror{
+		Backslash: backslash,
+	}
+}
+
+func (m *Mirror) Copy(dx, dy int) common.Node {
+	return &Mirror{
+		Backslash: m.Backslash,
+	}
+}
+
+func (m *Mirror) Route(dir common.Direction) common.Direction {
+	m.activated = common.PulsesPerStep + 1
+	if m.Backslash {
+		// A `\` mirror sends right to down and up to left.
+		return dir.FlipHorizontal().RotateCounterClockwise()
+	}
+	// A `/` mirror sends right to up and down to left.
+	return dir.FlipHorizontal().RotateClockwise()
+}
+
+func (m *Mirror) Activated() bool {
+	return m.activated > 0
+}
+
+func (m *Mirror) Direction() common.Direction {
+	return common.NONE
+}
+
+func (m *Mirror) SetDirection(dir common.Direction) {}
+
+func (m *Mirror) Tick() {
+	if m.activated <= 0 {
+		return
+	}
+	m.activated--
+}
+
+func (m *Mirror) Reset() {
+	m.activated = 0
+}
+
+func (m *Mirror) Symbol() string {
+	if m.Backslash {
+		return "M╲"
+	}
+	return "M╱"
+}
+
+func (m *Mirror) Name() string {
+	return "mirror"
+}
+
+func (m *Mirror) Color() string {
+	return "67"
+}
@@ -8,10 +8,10 @@ import (
 
 type PassEmitter struct{}
 
-func NewPassEmitter(midi midi.Midi, device *midi.Device, direction common.Direction) *Emitter {
+func NewPassEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *Emitter {
 	return &Emitter{
 		direction: direction,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 		behavior:  &PassEmitter{},
 	}
 }
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"signls/core/common"
+)
+
+// Rotator turns signals a quarter turn left or right without triggering
+// anything.
+type Rotator struct {
+	activated int
+	Clockwise bool
+}
+
+func NewRotator(clockwise bool) *Rotator {
+	return &Rotator{
+		Clockwise: clockwise,
+	}
+}
+
+func (r *Rotator) Copy(dx, dy int) common.Node {
+	return &Rotator{
+		Clockwise: r.Clockwise,
+	}
+}
+
+func (r *Rotator) Route(dir common.Direction) common.Direction {
+	r.activated = common.PulsesPerStep + 1
+	if r.Clockwise {
+		return dir.RotateClockwise()
+	}
+	return dir.RotateCounterClockwise()
+}
+
+func (r *Rotator) Activated() bool {
+	return r.activated > 0
+}
+
+func (r *Rotator) Direction() common.Direction {
+	return common.NONE
+}
+
+func (r *Rotator) SetDirection(dir common.Direction) {}
+
+func (r *Rotator) Tick() {
+	if r.activated <= 0 {
+		return
+	}
+	r.activated--
+}
+
+func (r *Rotator) Reset() {
+	r.activated = 0
+}
+
+func (r *Rotator) Symbol() string {
+	if r.Clockwise {
+		return "R↻"
+	}
+	return "R↺"
+}
+
+func (r *Rotator) Name() string {
+	return "rotator"
+}
+
+func (r *Rotator) Color() string {
+	return "97"
+}
@@ -6,22 +6,75 @@ import (
 
 type Signal struct {
 	direction common.Direction
+	rate      common.Rate
+	speed     common.Speed
+	lifetime  int
+	payload   common.Payload
+	age       int
 	pulse     uint64
 }
 
-func NewSignal(direction common.Direction, pulse uint64) *Signal {
+func NewSignal(direction common.Direction, pulse uint64, rate common.Rate, speed common.Speed, lifetime int, payload common.Payload) *Signal {
 	return &Signal{
 		direction: direction,
+		rate:      rate,
+		speed:     speed,
+		lifetime:  lifetime,
+		payload:   payload,
 		pulse:     pulse,
 	}
 }
 
 func (s *Signal) MustMove(pulse uint64) bool {
-	if !s.updated(pulse) {
-		s.pulse = pulse
-		return true
+	if s.updated(pulse) || !s.rate.IsStep(pulse) {
+		return false
 	}
-	return false
+	s.pulse = pulse
+	s.age++
+	return s.Expired() || s.age%s.speed.Steps() == 0
+}
+
+// Expired returns true once the signal lived for its whole lifetime, a zero
+// lifetime never expires.
+func (s *Signal) Expired() bool {
+	return s.lifetime > 0 && s.age >= s.lifetime
+}
+
+func (s *Signal) Cells() int {
+	return s.speed.Cells()
+}
+
+func (s *Signal) Rate() common.Rate {
+	return s.rate
+}
+
+func (s *Signal) SetRate(rate common.Rate) {
+	s.rate = rate
+}
+
+func (s *Signal) Speed() common.Speed {
+	return s.speed
+}
+
+func (s *Signal) SetSpeed(speed common.Speed) {
+	s.speed = speed
+}
+
+func (s *Signal) Lifetime() int {
+	return s.lifetime
+}
+
+func (s *Signal) SetLifetime(lifetime int) {
+	s.lifetime = lifetime
+}
+
+func (s *Signal) Payload() common.Payload {
+	return s.payload
+}
+
+func (s *Signal) SetPayload(payload common.Payload) {
+	s.payload.Transpose = payload.Transpose
+	s.payload.Velocity = payload.Velocity
 }
 
 func (s *Signal) Direction() common.Direction {
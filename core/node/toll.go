@@ -14,15 +14,17 @@ const (
 
 type TollEmitter struct {
 	Threshold *common.ControlValue[int]
+	rand      *common.Random
 	count     int
 }
 
-func NewTollEmitter(midi midi.Midi, device *midi.Device, direction common.Direction) *Emitter {
+func NewTollEmitter(midi midi.Midi, device *midi.Device, playback *music.Playback, direction common.Direction) *Emitter {
 	return &Emitter{
 		direction: direction,
-		note:      music.NewNote(midi, device),
+		note:      music.NewNote(midi, device, playback),
 		behavior: &TollEmitter{
 			Threshold: common.NewControlValue[int](defaultThreshold, 1, math.MaxInt32),
+			rand:      playback.Rand,
 		},
 	}
 }
@@ -33,7 +35,7 @@ func (e *TollEmitter) EmitDirections(dir common.Direction, inDir common.Directio
 		return common.NONE
 	}
 	e.count = 0
-	e.Threshold.Computed()
+	e.Threshold.Computed(e.rand)
 	return dir
 }
 
@@ -49,6 +51,7 @@ func (e *TollEmitter) Copy() common.EmitterBehavior {
 	newThreshold := *e.Threshold
 	return &TollEmitter{
 		Threshold: &newThreshold,
+		rand:      e.rand,
 	}
 }
 
@@ -66,4 +69,5 @@ func (e *TollEmitter) Color() string {
 
 func (e *TollEmitter) Reset() {
 	e.count = 0
+	e.Threshold.Rewind()
 }
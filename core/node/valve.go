@@ -0,0 +1,73 @@
+package node
+
+import (
+	"fmt"
+
+	"signls/core/common"
+)
+
+// Valve only lets through signals moving in one of its directions and
+// blocks all the others, without triggering anything.
+type Valve struct {
+	activated int
+	direction common.Direction
+}
+
+func NewValve(direction common.Direction) *Valve {
+	return &Valve{
+		direction: direction,
+	}
+}
+
+func (v *Valve) Copy(dx, dy int) common.Node {
+	return &Valve{
+		direction: v.direction,
+	}
+}
+
+func (v *Valve) Route(dir common.Direction) common.Direction {
+	if !v.direction.Contains(dir) {
+		return common.NONE
+	}
+	v.activated = common.PulsesPerStep + 1
+	return dir
+}
+
+func (v *Valve) Activated() bool {
+	return v.activated > 0
+}
+
+func (v *Valve) Direction() common.Direction {
+	return v.direction
+}
+
+func (v *Valve) SetDirection(dir common.Direction) {
+	if v.direction.Contains(dir) {
+		v.direction = v.direction.Remove(dir)
+		return
+	}
+	v.direction = v.direction.Add(dir)
+}
+
+func (v *Valve) Tick() {
+	if v.activated <= 0 {
+		return
+	}
+	v.activated--
+}
+
+func (v *Valve) Reset() {
+	v.activated = 0
+}
+
+func (v *Valve) Symbol() string {
+	return fmt.Sprintf("%s%s", "V", v.direction.Symbol())
+}
+
+func (v *Valve) Name() string {
+	return "valve"
+}
+
+func (v *Valve) Color() string {
+	return "130"
+}
@@ -0,0 +1,190 @@
+package theory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Chord constants represent chords as stacks of scale degrees, where each
+// bit corresponds to a degree above the chord key (bit 0 being the key
+// itself).
+const (
+	POWER   = Chord(1<<0 | 1<<4)
+	TRIAD   = Chord(1<<0 | 1<<2 | 1<<4)
+	SUS2    = Chord(1<<0 | 1<<1 | 1<<4)
+	SUS4    = Chord(1<<0 | 1<<3 | 1<<4)
+	SIXTH   = Chord(1<<0 | 1<<2 | 1<<4 | 1<<5)
+	SEVENTH = Chord(1<<0 | 1<<2 | 1<<4 | 1<<6)
+	NINTH   = Chord(1<<0 | 1<<2 | 1<<4 | 1<<6 | 1<<8)
+)
+
+// Voicing constants represent the different ways of arranging chord keys.
+const (
+	CLOSE Voicing = iota
+	OPEN
+	DROP2
+
+	MaxVoicing = DROP2
+)
+
+// MaxChordDegree is the highest scale degree a chord can stack.
+const MaxChordDegree = 14
+
+var (
+	allChords = []Chord{
+		TRIAD,
+		SEVENTH,
+		SUS2,
+		SUS4,
+		SIXTH,
+		NINTH,
+		POWER,
+	}
+
+	// chordNames maps each chord constant to its corresponding name.
+	chordNames = map[Chord]string{
+		POWER:   "power",
+		TRIAD:   "triad",
+		SUS2:    "sus2",
+		SUS4:    "sus4",
+		SIXTH:   "sixth",
+		SEVENTH: "seventh",
+		NINTH:   "ninth",
+	}
+
+	voicingNames = map[Voicing]string{
+		CLOSE: "close",
+		OPEN:  "open",
+		DROP2: "drop-2",
+	}
+)
+
+// Chord represents a chord using a bitwise integer, where each bit
+// corresponds to a scale degree above the chord key.
+type Chord uint16
+
+// AllChords returns a slice of all named chords.
+func AllChords() []Chord {
+	return allChords
+}
+
+// ParseChord parses a custom chord from a list of degrees counted from 1,
+// ex: "1 3 5 9".
+func ParseChord(input string) (Chord, error) {
+	var chord Chord
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '-' || r == '.'
+	}) {
+		degree, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, err
+		}
+		if degree < 1 || degree > MaxChordDegree+1 {
+			return 0, fmt.Errorf("degree %d out of range", degree)
+		}
+		chord |= 1 << (degree - 1)
+	}
+	if chord == 0 {
+		return 0, errors.New("empty chord")
+	}
+	return chord, nil
+}
+
+// Name returns the name of the chord, or its degrees counted from 1 for
+// custom chords.
+func (c Chord) Name() string {
+	if name, ok := chordNames[c]; ok {
+		return name
+	}
+	degrees := make([]string, len(c.Degrees()))
+	for i, d := range c.Degrees() {
+		degrees[i] = strconv.Itoa(d + 1)
+	}
+	return strings.Join(degrees, ".")
+}
+
+// Degrees returns the scale degrees that make up the chord, the chord key
+// being degree 0.
+func (c Chord) Degrees() []int {
+	degrees := []int{}
+	for i := 0; i <= MaxChordDegree; i++ {
+		if c&(1<<i) != 0 {
+			degrees = append(degrees, i)
+		}
+	}
+	return degrees
+}
+
+// Keys returns the keys of the chord stacked on the given key, in close
+// position, following the scale relative to the root key. A key out of
+// the scale is moved up to the next key in scale. The chromatic scale
+// falls back to the ionian scale, as stacking semitones makes clusters
+// rather than chords.
+func (c Chord) Keys(key Key, root Key, scale Scale) []Key {
+	if scale == CHROMATIC {
+		scale = IONIAN
+	}
+	keys := AllKeysInScale(root, scale)
+	start, _ := slices.BinarySearch(keys, key)
+	chord := []Key{}
+	for _, d := range c.Degrees() {
+		if start+d >= len(keys) || keys[start+d] > 127 {
+			break
+		}
+		chord = append(chord, keys[start+d])
+	}
+	return chord
+}
+
+// Voicing represents the arrangement of the keys of a chord.
+type Voicing int
+
+// Name returns the name of the voicing.
+func (v Voicing) Name() string {
+	return voicingNames[v]
+}
+
+// Voice arranges chord keys given in close position, after moving the
+// given number of lowest keys up an octave for inversions. Keys are
+// returned in the chord stacking order (key, third, fifth...), those out
+// of the midi range are dropped.
+func (v Voicing) Voice(keys []Key, inversion int) []Key {
+	voiced := make([]int, len(keys))
+	for i, k := range keys {
+		voiced[i] = int(k)
+		if i < inversion%max(len(keys), 1) {
+			voiced[i] += 12
+		}
+	}
+
+	// Voicings move keys according to their position from the lowest one.
+	order := make([]int, len(voiced))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return voiced[a] - voiced[b]
+	})
+	switch v {
+	case OPEN:
+		for i := 1; i < len(order); i += 2 {
+			voiced[order[i]] += 12
+		}
+	case DROP2:
+		if len(order) > 2 {
+			voiced[order[len(order)-2]] -= 12
+		}
+	}
+
+	chord := make([]Key, 0, len(voiced))
+	for _, k := range voiced {
+		if k < 0 || k > 127 {
+			continue
+		}
+		chord = append(chord, Key(k))
+	}
+	return chord
+}
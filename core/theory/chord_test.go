@@ -0,0 +1,51 @@
+package theory
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChordKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       Key
+		root      Key
+		scale     Scale
+		chord     Chord
+		voicing   Voicing
+		inversion int
+		want      []Key
+	}{
+		{name: "triad", key: 60, root: 60, scale: IONIAN, chord: TRIAD, want: []Key{60, 64, 67}},
+		{name: "minor triad", key: 62, root: 60, scale: IONIAN, chord: TRIAD, want: []Key{62, 65, 69}},
+		{name: "seventh", key: 60, root: 60, scale: AEOLIAN, chord: SEVENTH, want: []Key{60, 63, 67, 70}},
+		{name: "sus4", key: 60, root: 60, scale: IONIAN, chord: SUS4, want: []Key{60, 65, 67}},
+		{name: "chromatic", key: 62, root: 60, scale: CHROMATIC, chord: TRIAD, want: []Key{62, 65, 69}},
+		{name: "out of scale", key: 61, root: 60, scale: IONIAN, chord: TRIAD, want: []Key{62, 65, 69}},
+		{name: "first inversion", key: 60, root: 60, scale: IONIAN, chord: TRIAD, inversion: 1, want: []Key{72, 64, 67}},
+		{name: "open", key: 60, root: 60, scale: IONIAN, chord: TRIAD, voicing: OPEN, want: []Key{60, 76, 67}},
+		{name: "drop-2", key: 60, root: 60, scale: IONIAN, chord: SEVENTH, voicing: DROP2, want: []Key{60, 64, 55, 71}},
+		{name: "top of range", key: 124, root: 60, scale: IONIAN, chord: TRIAD, want: []Key{124, 127}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.voicing.Voice(tt.chord.Keys(tt.key, tt.root, tt.scale), tt.inversion)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got keys %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChord(t *testing.T) {
+	chord, err := ParseChord("1 3 5 9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chord != TRIAD|1<<8 || chord.Name() != "1.3.5.9" {
+		t.Errorf("got chord %s", chord.Name())
+	}
+	if _, err := ParseChord("0 3"); err == nil {
+		t.Error("expected an error for degree 0")
+	}
+}
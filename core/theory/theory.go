@@ -190,6 +190,25 @@ func AllKeysInScale(root Key, scale Scale) []Key {
 	return keys
 }
 
+// ClosestScale returns the first scale that contains all the given keys,
+// relative to the root key. It falls back to the chromatic scale if no
+// other scale matches.
+func ClosestScale(root Key, keys []Key) Scale {
+	var chord Scale
+	for _, k := range keys {
+		chord |= 1 << k.SemitonesFrom(root)
+	}
+	for _, scale := range allScales {
+		if scale == CHROMATIC {
+			continue
+		}
+		if scale&chord == chord {
+			return scale
+		}
+	}
+	return CHROMATIC
+}
+
 // Name returns the name of the scale based on its bitwise representation.
 func (s Scale) Name() string {
 	if name, ok := scaleNames[s]; ok {
@@ -27,3 +27,24 @@ func TestKeyTransposition(t *testing.T) {
 		}
 	}
 }
+
+func TestClosestScale(t *testing.T) {
+	tests := []struct {
+		root Key
+		keys []Key
+		want Scale
+	}{
+		{Key(60), []Key{60, 64, 67}, IONIAN},
+		{Key(60), []Key{60, 63, 67}, DORIAN},
+		{Key(62), []Key{62, 65, 69, 72}, DORIAN},
+		{Key(60), []Key{60, 64, 67, 70}, MIXOLYDIAN},
+		{Key(60), []Key{60, 63, 66}, LOCRIAN},
+		{Key(60), []Key{60, 61, 62}, CHROMATIC},
+	}
+	for _, tt := range tests {
+		scale := ClosestScale(tt.root, tt.keys)
+		if scale != tt.want {
+			t.Fatalf("%v from %s should match %s scale, got %s", tt.keys, tt.root.Name(), tt.want.Name(), scale.Name())
+		}
+	}
+}
@@ -20,12 +20,20 @@ import (
 
 const (
 	defaultTempo                = 120.
+	defaultSwing                = 50
 	defaultRootKey theory.Key   = 60 // Middle C
 	defaultScale   theory.Scale = theory.CHROMATIC
 	defaultSize                 = 20
 	maxGrids                    = 32
 )
 
+// Grid format versions, saved with each grid so that grids saved by older
+// versions can be converted on load.
+const (
+	LegacyGridVersion = 0 // Grids saved before versions, infinite lengths being stored as 127.
+	GridVersion       = 1 // Note lengths in pulses, up to 16 bars.
+)
+
 // Bank holds a slice of grids in memory
 type Bank struct {
 	mu sync.Mutex
@@ -37,19 +45,33 @@ type Bank struct {
 
 // Grid holds a grid in memory
 type Grid struct {
+	Version int `json:"version"` // Format version the grid was saved with.
+
 	Nodes []Node  `json:"nodes"`
 	Tempo float64 `json:"tempo"`
+	Swing int     `json:"swing"`
 
 	Height int `json:"height"`
 	Width  int `json:"width"`
 
-	Device string `json:"device"`
+	Device      string `json:"device"`
+	InputDevice string `json:"input_device"`
 
 	Key   uint8  `json:"key"`
 	Scale uint16 `json:"scale"`
 
 	SendClock     bool `json:"send_clock"`
 	SendTransport bool `json:"send_transport"`
+	ExternalClock bool `json:"external_clock"`
+
+	TransposeChannel int  `json:"transpose_channel"`
+	TransposeScale   bool `json:"transpose_scale"`
+	FillChannel      int  `json:"fill_channel"`
+
+	Seed      int64 `json:"seed"`
+	ResetSeed bool  `json:"reset_seed"`
+
+	Edge int `json:"edge"`
 }
 
 // NewGrid creates a new grid with default values.
@@ -59,6 +81,7 @@ func NewGrid() Grid {
 		Height: defaultSize,
 		Width:  defaultSize,
 		Tempo:  defaultTempo,
+		Swing:  defaultSwing,
 		Key:    uint8(defaultRootKey),
 		Scale:  uint16(defaultScale),
 	}
@@ -87,9 +110,15 @@ type Note struct {
 	Channel      Param                  `json:"channel"`
 	Velocity     Param                  `json:"velocity"`
 	Length       Param                  `json:"length"`
+	Legato       bool                   `json:"legato"`
 	Probability  int                    `json:"probability"`
+	Condition    Condition              `json:"condition"`
+	Fill         int                    `json:"fill"`
+	Nudge        int                    `json:"nudge"`
+	Ratchet      Ratchet                `json:"ratchet"`
 	Controls     []CC                   `json:"controls"`
 	MetaCommands map[string]MetaCommand `json:"meta_commands"`
+	Input        InputTrigger           `json:"input"`
 }
 
 func NewNote(n music.Note) Note {
@@ -106,23 +135,63 @@ func NewNote(n music.Note) Note {
 		Channel:      NewParam(*n.Channel),
 		Velocity:     NewParam(*n.Velocity),
 		Length:       NewParam(*n.Length),
+		Legato:       n.Legato,
 		Probability:  int(n.Probability),
+		Condition:    NewCondition(n.Condition),
+		Fill:         int(n.Fill),
+		Nudge:        n.Nudge,
+		Ratchet:      NewRatchet(n.Ratchet),
 		Controls:     controls,
 		MetaCommands: metaCmds,
+		Input:        NewInputTrigger(n.Input),
 	}
 }
 
 type Key struct {
-	Key    int
-	Amount int
-	Silent bool
+	Key          int
+	Amount       int
+	Silent       bool
+	Sequence     []int `json:",omitempty"` // Intervals from the grid root.
+	Mode         int   `json:",omitempty"`
+	Distribution int   `json:",omitempty"`
 }
 
 func NewKey(key music.KeyValue) Key {
 	return Key{
-		Key:    int(key.BaseValue()),
-		Amount: key.RandomAmount(),
-		Silent: key.IsSilent(),
+		Key:          int(key.BaseValue()),
+		Amount:       key.RandomAmount(),
+		Silent:       key.IsSilent(),
+		Sequence:     key.Sequence().Values(),
+		Mode:         int(key.Sequence().Mode()),
+		Distribution: int(key.Distribution()),
+	}
+}
+
+type Condition struct {
+	Type int `json:"type"`
+	A    int `json:"a"`
+	B    int `json:"b"`
+}
+
+func NewCondition(c music.Condition) Condition {
+	return Condition{
+		Type: int(c.Type),
+		A:    c.A,
+		B:    c.B,
+	}
+}
+
+type Ratchet struct {
+	Count int `json:"count"`
+	Rate  int `json:"rate"`
+	Decay int `json:"decay"`
+}
+
+func NewRatchet(r music.Ratchet) Ratchet {
+	return Ratchet{
+		Count: r.Count,
+		Rate:  r.Rate,
+		Decay: r.Decay,
 	}
 }
 
@@ -152,18 +221,57 @@ func NewMetaCommand(cmd meta.Command) MetaCommand {
 	}
 }
 
+type InputTrigger struct {
+	Active   bool `json:"active"`
+	Key      int  `json:"key"`
+	Channel  int  `json:"channel"`
+	Velocity bool `json:"velocity"`
+}
+
+func NewInputTrigger(t music.InputTrigger) InputTrigger {
+	return InputTrigger{
+		Active:   t.Active,
+		Key:      int(t.Key),
+		Channel:  int(t.Channel),
+		Velocity: t.Velocity,
+	}
+}
+
 type Param struct {
-	Value  int
-	Amount int
+	Value        int
+	Amount       int
+	Sequence     []int `json:",omitempty"`
+	Mode         int   `json:",omitempty"`
+	Distribution int   `json:",omitempty"`
 }
 
 func NewParam[T uint8 | int](p common.ControlValue[T]) Param {
+	var sequence []int
+	for _, v := range p.Sequence().Values() {
+		sequence = append(sequence, int(v))
+	}
 	return Param{
-		Value:  int(p.Value()),
-		Amount: p.RandomAmount(),
+		Value:        int(p.Value()),
+		Amount:       p.RandomAmount(),
+		Sequence:     sequence,
+		Mode:         int(p.Sequence().Mode()),
+		Distribution: int(p.Distribution()),
 	}
 }
 
+// NewBoolParam returns a param holding a boolean value.
+func NewBoolParam(value bool) Param {
+	if value {
+		return Param{Value: 1}
+	}
+	return Param{}
+}
+
+// Bool returns the boolean value of a param.
+func (p Param) Bool() bool {
+	return p.Value != 0
+}
+
 // New creates and loads a new bank from a given file.
 func New(filename string) *Bank {
 	grids := make([]Grid, maxGrids)
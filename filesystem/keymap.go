@@ -19,6 +19,11 @@ type KeyMap struct {
 	EditDown  string `json:"edit_down"`
 	EditLeft  string `json:"edit_left"`
 
+	EditUpRight   string `json:"edit_up_right"`
+	EditDownRight string `json:"edit_down_right"`
+	EditDownLeft  string `json:"edit_down_left"`
+	EditUpLeft    string `json:"edit_up_left"`
+
 	EditInput string `json:"edit_input"`
 
 	Bank string `json:"bank"`
@@ -32,6 +37,12 @@ type KeyMap struct {
 	AddToll   string `json:"add_toll"`
 	AddZone   string `json:"add_zone"`
 	AddHole   string `json:"add_hole"`
+	AddChord  string `json:"add_chord"`
+	AddArp    string `json:"add_arp"`
+
+	AddMirror  string `json:"add_mirror"`
+	AddRotator string `json:"add_rotator"`
+	AddValve   string `json:"add_valve"`
 
 	Copy  string `json:"copy"`
 	Cut   string `json:"cut"`
@@ -44,6 +55,8 @@ type KeyMap struct {
 	MuteNode    string `json:"mute_node"`
 	MuteAllNode string `json:"mute_all_node"`
 
+	Fill string `json:"fill"`
+
 	RootNoteUp   string `json:"root_note_up"`
 	RootNoteDown string `json:"root_note_down"`
 	ScaleUp      string `json:"scale_up"`
@@ -80,6 +93,11 @@ func NewDefaultAzertyKeyMap() KeyMap {
 		EditDown:  "ctrl+down",
 		EditLeft:  "ctrl+left",
 
+		EditUpRight:   "ctrl+shift+up",
+		EditDownRight: "ctrl+shift+right",
+		EditDownLeft:  "ctrl+shift+down",
+		EditUpLeft:    "ctrl+shift+left",
+
 		EditInput: ":",
 
 		Bank: "tab",
@@ -93,6 +111,12 @@ func NewDefaultAzertyKeyMap() KeyMap {
 		AddToll:   "è",
 		AddZone:   "_",
 		AddHole:   "ç",
+		AddChord:  "k",
+		AddArp:    "a",
+
+		AddMirror:  "à",
+		AddRotator: "r",
+		AddValve:   "v",
 
 		Copy:  "ctrl+c",
 		Cut:   "ctrl+x",
@@ -105,6 +129,8 @@ func NewDefaultAzertyKeyMap() KeyMap {
 		MuteNode:    "m",
 		MuteAllNode: "M",
 
+		Fill: "f",
+
 		RootNoteUp:   "*",
 		RootNoteDown: "ù",
 		ScaleUp:      "µ",
@@ -143,6 +169,11 @@ func NewDefaultAzertyMacKeyMap() KeyMap {
 		EditDown:  "ctrl+down",
 		EditLeft:  "ctrl+left",
 
+		EditUpRight:   "ctrl+shift+up",
+		EditDownRight: "ctrl+shift+right",
+		EditDownLeft:  "ctrl+shift+down",
+		EditUpLeft:    "ctrl+shift+left",
+
 		EditInput: ":",
 
 		Bank: "tab",
@@ -156,6 +187,12 @@ func NewDefaultAzertyMacKeyMap() KeyMap {
 		AddToll:   "è",
 		AddZone:   "!",
 		AddHole:   "ç",
+		AddChord:  "k",
+		AddArp:    "a",
+
+		AddMirror:  "à",
+		AddRotator: "r",
+		AddValve:   "v",
 
 		Copy:  "ctrl+c",
 		Cut:   "ctrl+x",
@@ -168,6 +205,8 @@ func NewDefaultAzertyMacKeyMap() KeyMap {
 		MuteNode:    "m",
 		MuteAllNode: "M",
 
+		Fill: "f",
+
 		RootNoteUp:   "`",
 		RootNoteDown: "ù",
 		ScaleUp:      "£",
@@ -205,6 +244,11 @@ func NewDefaultQwertyKeyMap() KeyMap {
 		EditDown:  "ctrl+down",
 		EditLeft:  "ctrl+left",
 
+		EditUpRight:   "ctrl+shift+up",
+		EditDownRight: "ctrl+shift+right",
+		EditDownLeft:  "ctrl+shift+down",
+		EditUpLeft:    "ctrl+shift+left",
+
 		EditInput: ".",
 
 		Bank: "tab",
@@ -218,6 +262,12 @@ func NewDefaultQwertyKeyMap() KeyMap {
 		AddToll:   "7",
 		AddZone:   "8",
 		AddHole:   "9",
+		AddChord:  "k",
+		AddArp:    "a",
+
+		AddMirror:  "0",
+		AddRotator: "r",
+		AddValve:   "v",
 
 		Copy:  "ctrl+c",
 		Cut:   "ctrl+x",
@@ -230,6 +280,8 @@ func NewDefaultQwertyKeyMap() KeyMap {
 		MuteNode:    "m",
 		MuteAllNode: "M",
 
+		Fill: "f",
+
 		RootNoteUp:   "'",
 		RootNoteDown: ";",
 		ScaleUp:      "\"",
@@ -268,6 +320,11 @@ func NewDefaultQwertyMacKeyMap() KeyMap {
 		EditDown:  "ctrl+down",
 		EditLeft:  "ctrl+left",
 
+		EditUpRight:   "ctrl+shift+up",
+		EditDownRight: "ctrl+shift+right",
+		EditDownLeft:  "ctrl+shift+down",
+		EditUpLeft:    "ctrl+shift+left",
+
 		EditInput: ".",
 
 		Bank: "tab",
@@ -281,6 +338,12 @@ func NewDefaultQwertyMacKeyMap() KeyMap {
 		AddToll:   "7",
 		AddZone:   "8",
 		AddHole:   "9",
+		AddChord:  "k",
+		AddArp:    "a",
+
+		AddMirror:  "0",
+		AddRotator: "r",
+		AddValve:   "v",
 
 		Copy:  "ctrl+c",
 		Cut:   "ctrl+x",
@@ -293,6 +356,8 @@ func NewDefaultQwertyMacKeyMap() KeyMap {
 		MuteNode:    "m",
 		MuteAllNode: "M",
 
+		Fill: "f",
+
 		RootNoteUp:   "'",
 		RootNoteDown: ";",
 		ScaleUp:      "\"",
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"signls/core/field"
+	"signls/filesystem"
+)
+
+// bankRefreshFrequency is the frequency at which grid changes requested by
+// bank meta commands are checked in headless mode.
+const bankRefreshFrequency = 33 * time.Millisecond
+
+// runHeadless plays the grid without the terminal ui, until SIGINT or
+// SIGTERM is received. Grid changes requested by bank meta commands are
+// loaded from the bank, as the ui does.
+func runHeadless(grid *field.Grid, bank *filesystem.Bank) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(bankRefreshFrequency)
+	defer ticker.Stop()
+
+	log.Printf("playing grid %d of %s", bank.Active+1, bank.Filename())
+	grid.TogglePlay()
+	for {
+		select {
+		case s := <-signals:
+			log.Printf("received %s, stopping", s)
+			// Stopping resets the grid and silences all notes.
+			grid.TogglePlay()
+			return
+		case <-ticker.C:
+			var index int
+			grid.Read(func() {
+				index = grid.BankIndex
+			})
+			if index == bank.Active {
+				continue
+			}
+			bank.Active = index
+			grid.Load(bank.Active, bank.ActiveGrid())
+			grid.SetPlaying(true)
+			log.Printf("playing grid %d of %s", bank.Active+1, bank.Filename())
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"signls/core/field"
 	"signls/filesystem"
 	"signls/midi"
+	"signls/render"
 	"signls/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,11 +21,17 @@ import (
 var AppVersion string
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		renderBank(os.Args[2:])
+		return
+	}
+
 	configFile := flag.String("config", "config.json", "config file to load or create")
 	bankFile := flag.String("bank", "default.json", "bank file to store grids")
 	keyboard := flag.String("keyboard", "", "keyboard layout (qwerty, qwerty-mac, azerty, azerty-mac)")
 	version := flag.Bool("version", false, "print current version")
 	debug := flag.Bool("debug", false, "enable debug mode")
+	headless := flag.Bool("headless", false, "play the active grid without the terminal ui")
 	flag.Parse()
 
 	if *version {
@@ -51,8 +58,42 @@ func main() {
 	bank := filesystem.New(*bankFile)
 	grid := field.NewFromBank(bank.Active, bank.ActiveGrid(), midi)
 
+	if *headless {
+		runHeadless(grid, bank)
+		return
+	}
+
 	p := tea.NewProgram(ui.New(config, grid, bank))
 	if _, err := p.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// renderBank renders a grid of a bank to a midi file:
+// signls render --bank x.json --grid 3 --bars 64 out.mid
+func renderBank(args []string) {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	bankFile := flags.String("bank", "default.json", "bank file to render")
+	grid := flags.Int("grid", 1, "grid to render (1-32)")
+	bars := flags.Int("bars", 16, "number of bars to render")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s render [options] out.mid\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := os.Stat(*bankFile); err != nil {
+		log.Fatal(err)
+	}
+	s, err := render.Render(filesystem.New(*bankFile), *grid-1, *bars)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := s.WriteFile(flags.Arg(0)); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,241 @@
+package midi
+
+import (
+	"fmt"
+	"sync"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
+)
+
+const (
+	// Each subscriber receives messages through a dedicated buffered chan.
+	// Midi clock alone sends 24 messages per quarter note, so we keep
+	// some room for slow subscribers.
+	inputBufferSize = 1024
+)
+
+// Input provides a way to receive messages from midi devices.
+type Input interface {
+	Inputs() gomidi.InPorts
+	NewInput(device string) Device
+	GetInput(device int) Device
+	Subscribe(device int) (<-chan gomidi.Message, error)
+	Unsubscribe(device int, messages <-chan gomidi.Message)
+}
+
+// dispatcher holds the subscribers of each input device and dispatches
+// incoming messages to them.
+type dispatcher struct {
+	mu          sync.Mutex
+	subscribers map[int][]chan gomidi.Message
+}
+
+// subscribe registers a new subscriber for the given device. It returns
+// true if it's the first subscriber of the device.
+func (d *dispatcher) subscribe(device int) (chan gomidi.Message, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.subscribers == nil {
+		d.subscribers = map[int][]chan gomidi.Message{}
+	}
+	sub := make(chan gomidi.Message, inputBufferSize)
+	d.subscribers[device] = append(d.subscribers[device], sub)
+	return sub, len(d.subscribers[device]) == 1
+}
+
+// unsubscribe removes and closes a subscriber. It returns true if the
+// device has no subscribers left.
+func (d *dispatcher) unsubscribe(device int, messages <-chan gomidi.Message) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	subs := d.subscribers[device]
+	for i, sub := range subs {
+		if sub != messages {
+			continue
+		}
+		close(sub)
+		d.subscribers[device] = append(subs[:i], subs[i+1:]...)
+		break
+	}
+	return len(d.subscribers[device]) == 0
+}
+
+// dispatch sends a message to all the subscribers of a device. Messages
+// are dropped for subscribers that are not keeping up, so that the midi
+// driver is never blocked.
+func (d *dispatcher) dispatch(device int, msg gomidi.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, sub := range d.subscribers[device] {
+		select {
+		case sub <- msg:
+		default:
+		}
+	}
+}
+
+// close closes all the subscribers.
+func (d *dispatcher) close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for device, subs := range d.subscribers {
+		for _, sub := range subs {
+			close(sub)
+		}
+		delete(d.subscribers, device)
+	}
+}
+
+// listeners dispatches messages to the subscribers of each device, the
+// device being listened to as long as it has subscribers. Each device has
+// its own lock, held while its listener is started or stopped, so that
+// subscriptions never race with the listener setup of the same device.
+type listeners struct {
+	dispatcher
+
+	listenMu sync.Mutex
+	locks    map[int]*sync.Mutex // Lock of each device.
+	stops    map[int]func()      // Functions stopping the listened devices.
+}
+
+// lock returns the lock of a device.
+func (l *listeners) lock(device int) *sync.Mutex {
+	l.listenMu.Lock()
+	defer l.listenMu.Unlock()
+	if l.locks == nil {
+		l.locks = map[int]*sync.Mutex{}
+	}
+	if _, ok := l.locks[device]; !ok {
+		l.locks[device] = &sync.Mutex{}
+	}
+	return l.locks[device]
+}
+
+// listen registers a new subscriber for the given device. On the first
+// subscription, the device starts being listened to with start, which
+// returns the function stopping it.
+func (l *listeners) listen(device int, start func() (func(), error)) (chan gomidi.Message, error) {
+	lock := l.lock(device)
+	lock.Lock()
+	defer lock.Unlock()
+	sub, first := l.subscribe(device)
+	if !first {
+		return sub, nil
+	}
+	stop, err := start()
+	if err != nil {
+		l.unsubscribe(device, sub)
+		return nil, err
+	}
+	l.listenMu.Lock()
+	if l.stops == nil {
+		l.stops = map[int]func(){}
+	}
+	l.stops[device] = stop
+	l.listenMu.Unlock()
+	return sub, nil
+}
+
+// unlisten removes and closes a subscriber. The device stops being
+// listened to when it has no subscribers left.
+func (l *listeners) unlisten(device int, messages <-chan gomidi.Message) {
+	lock := l.lock(device)
+	lock.Lock()
+	defer lock.Unlock()
+	if !l.unsubscribe(device, messages) {
+		return
+	}
+	l.listenMu.Lock()
+	stop, ok := l.stops[device]
+	delete(l.stops, device)
+	l.listenMu.Unlock()
+	// The listener is stopped outside of the dispatcher lock as it might
+	// be dispatching a message.
+	if ok {
+		stop()
+	}
+}
+
+// close stops listening to all devices and closes all subscribers.
+func (l *listeners) close() {
+	l.listenMu.Lock()
+	stops := l.stops
+	l.stops = nil
+	l.listenMu.Unlock()
+	for _, stop := range stops {
+		stop()
+	}
+	l.dispatcher.close()
+}
+
+// input contains the midi input devices state.
+type input struct {
+	listeners
+
+	// ports holds all the midi devices inputs that are returned by gomidi.
+	ports gomidi.InPorts
+}
+
+func newInput(ports gomidi.InPorts) *input {
+	return &input{
+		ports: ports,
+	}
+}
+
+// Inputs returns all in ports.
+func (in *input) Inputs() gomidi.InPorts {
+	return in.ports
+}
+
+// NewInput creates a new input device. If the device is not connected,
+// it's flagged as fallback and cannot be subscribed to.
+func (in *input) NewInput(device string) Device {
+	for i, d := range in.ports {
+		if d.String() == device {
+			return Device{
+				Name: device,
+				ID:   i,
+			}
+		}
+	}
+	return Device{
+		Name:     device,
+		ID:       defaultDevice,
+		Fallback: true,
+	}
+}
+
+// GetInput get a midi input device per index.
+func (in *input) GetInput(device int) Device {
+	if len(in.ports) == 0 {
+		return Device{Fallback: true}
+	}
+	if len(in.ports)-1 < device {
+		return Device{Name: in.ports[0].String()}
+	}
+	if device < 0 {
+		index := len(in.ports) - 1
+		return Device{Name: in.ports[index].String(), ID: index}
+	}
+	return Device{Name: in.ports[device].String(), ID: device}
+}
+
+// Subscribe returns a chan receiving every midi message, including
+// realtime messages (clock, start, stop, ...), sent by the given input
+// device. The device starts being listened to on its first subscription.
+func (in *input) Subscribe(device int) (<-chan gomidi.Message, error) {
+	if device < 0 || device >= len(in.ports) {
+		return nil, fmt.Errorf("input device %d not connected", device)
+	}
+	return in.listen(device, func() (func(), error) {
+		return gomidi.ListenTo(in.ports[device], func(msg gomidi.Message, _ int32) {
+			in.dispatch(device, msg)
+		}, gomidi.UseTimeCode())
+	})
+}
+
+// Unsubscribe closes the given subscriber chan. The device stops being
+// listened to when it has no subscribers left.
+func (in *input) Unsubscribe(device int, messages <-chan gomidi.Message) {
+	in.unlisten(device, messages)
+}
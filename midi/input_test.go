@@ -0,0 +1,61 @@
+package midi
+
+import (
+	"sync"
+	"testing"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
+)
+
+func TestInputMockDispatch(t *testing.T) {
+	input := &InputMock{}
+	first, _ := input.Subscribe(0)
+	second, _ := input.Subscribe(0)
+	other, _ := input.Subscribe(1)
+
+	input.Send(0, gomidi.NoteOn(0, 60, 100))
+
+	for _, sub := range []<-chan gomidi.Message{first, second} {
+		var channel, key, velocity uint8
+		if msg := <-sub; !msg.GetNoteOn(&channel, &key, &velocity) || key != 60 {
+			t.Fatalf("subscriber should receive note on 60, got %s", msg)
+		}
+	}
+	if len(other) != 0 {
+		t.Fatalf("subscriber of another device should not receive messages")
+	}
+
+	input.Unsubscribe(0, first)
+	if _, ok := <-first; ok {
+		t.Fatalf("unsubscribed chan should be closed")
+	}
+	input.Send(0, gomidi.TimingClock())
+	if msg := <-second; !msg.Is(gomidi.TimingClockMsg) {
+		t.Fatalf("remaining subscriber should receive timing clock, got %s", msg)
+	}
+}
+
+func TestInputMockSubscribeRace(t *testing.T) {
+	input := &InputMock{}
+	for range 100 {
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sub, _ := input.Subscribe(0)
+				input.Unsubscribe(0, sub)
+			}()
+		}
+		wg.Wait()
+		if n := input.Listening(); n != 0 {
+			t.Fatalf("device without subscribers should not be listened to, %d listeners left", n)
+		}
+	}
+
+	sub, _ := input.Subscribe(0)
+	if n := input.Listening(); n != 1 {
+		t.Fatalf("subscribed device should have 1 listener, got %d", n)
+	}
+	input.Unsubscribe(0, sub)
+}
@@ -37,6 +37,7 @@ type Midi interface {
 	TransportStop(device int)
 	NewDevice(device, fallback string) Device
 	GetDevice(device int) Device
+	Input
 	Close()
 }
 
@@ -57,6 +58,10 @@ type midi struct {
 	// devices holds all the midi devices outputs that are returned by gomidi.
 	devices gomidi.OutPorts
 
+	// input holds all the midi devices inputs and dispatches incoming
+	// messages to subscribers.
+	*input
+
 	// Because we want to allow the usage of multiple midi devices at the same
 	// time, we start a goroutine for each device that can receive note trigs.
 	// The wait group is used when closing the midi devices (waits for all
@@ -75,9 +80,14 @@ func New() (Midi, error) {
 	if err != nil {
 		return nil, err
 	}
+	virtualInput, err := drivers.Get().(*rtmidi.Driver).OpenVirtualIn("Signls Input")
+	if err != nil {
+		return nil, err
+	}
 	devices := gomidi.GetOutPorts()
 	midi := &midi{
 		devices: append(devices, virtualDevice),
+		input:   newInput(append(gomidi.GetInPorts(), virtualInput)),
 	}
 	midi.start()
 	return midi, nil
@@ -246,6 +256,7 @@ func (m *midi) GetDevice(device int) Device {
 // Close terminates all the device goroutines gracefully.
 func (m *midi) Close() {
 	defer gomidi.CloseDriver()
+	m.input.close()
 	if m.waitGroup == nil {
 		return
 	}
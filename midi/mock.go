@@ -1,10 +1,16 @@
 package midi
 
 import (
+	"sync/atomic"
+
 	gomidi "gitlab.com/gomidi/midi/v2"
 )
 
-type Mock struct{}
+// Mock is a midi implementation that does nothing. It can be used for
+// testing the engine without any midi device.
+type Mock struct {
+	InputMock
+}
 
 func (m *Mock) Devices() gomidi.OutPorts                                     { return nil }
 func (m *Mock) NoteOn(device int, channel uint8, note uint8, velocity uint8) {}
@@ -21,3 +27,38 @@ func (m *Mock) TransportStop(device int)                                     {}
 func (m *Mock) NewDevice(device, fallback string) Device                     { return Device{} }
 func (m *Mock) GetDevice(device int) Device                                  { return Device{} }
 func (m *Mock) Close()                                                       {}
+
+// InputMock is a midi input implementation without any midi device.
+// Messages are sent to subscribers with Send.
+type InputMock struct {
+	listeners
+
+	listening atomic.Int32 // Number of devices listened to.
+}
+
+func (m *InputMock) Inputs() gomidi.InPorts        { return nil }
+func (m *InputMock) NewInput(device string) Device { return Device{Name: device} }
+func (m *InputMock) GetInput(device int) Device    { return Device{ID: device} }
+
+func (m *InputMock) Subscribe(device int) (<-chan gomidi.Message, error) {
+	return m.listen(device, func() (func(), error) {
+		m.listening.Add(1)
+		return func() { m.listening.Add(-1) }, nil
+	})
+}
+
+func (m *InputMock) Unsubscribe(device int, messages <-chan gomidi.Message) {
+	m.unlisten(device, messages)
+}
+
+// Listening returns the number of devices listened to, each device being
+// listened to as long as it has subscribers.
+func (m *InputMock) Listening() int {
+	return int(m.listening.Load())
+}
+
+// Send dispatches a message to all the subscribers of the given device,
+// as if it was sent by a midi device.
+func (m *InputMock) Send(device int, msg gomidi.Message) {
+	m.dispatch(device, msg)
+}
@@ -0,0 +1,189 @@
+package midi
+
+import (
+	"slices"
+	"sync"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
+)
+
+// Event is a midi message recorded at a given position.
+type Event struct {
+	Position uint64
+	Device   int
+	Message  gomidi.Message
+}
+
+// recordedNote is a note being played on a recorded device.
+type recordedNote struct {
+	device  int
+	channel uint8
+	key     uint8
+}
+
+// Recorder is a midi implementation that records messages instead of
+// sending them to midi devices. Messages are stamped with the position set
+// with SetPosition, which lets the engine be rendered offline.
+type Recorder struct {
+	InputMock
+
+	mu       sync.Mutex
+	position uint64
+	devices  []string
+	playing  map[recordedNote]struct{}
+	events   []Event
+}
+
+// NewRecorder creates a new midi recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		playing: map[recordedNote]struct{}{},
+	}
+}
+
+// SetPosition sets the position of the next recorded messages.
+func (r *Recorder) SetPosition(position uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.position = position
+}
+
+// Events returns all the recorded events, ordered by position.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.events)
+}
+
+// DeviceName returns the name of a recorded device.
+func (r *Recorder) DeviceName(device int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if device < 0 || device >= len(r.devices) {
+		return ""
+	}
+	return r.devices[device]
+}
+
+func (r *Recorder) Devices() gomidi.OutPorts { return nil }
+
+func (r *Recorder) NoteOn(device int, channel uint8, note uint8, velocity uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.playing[recordedNote{device, channel, note}] = struct{}{}
+	r.record(device, gomidi.NoteOn(channel, note, velocity))
+}
+
+func (r *Recorder) NoteOff(device int, channel uint8, note uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.noteOff(recordedNote{device, channel, note})
+}
+
+func (r *Recorder) Silence(device int, channel uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range r.sortedPlaying() {
+		if n.device == device && n.channel == channel {
+			r.noteOff(n)
+		}
+	}
+}
+
+func (r *Recorder) SilenceAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range r.sortedPlaying() {
+		r.noteOff(n)
+	}
+}
+
+func (r *Recorder) ControlChange(device int, channel, controller, value uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(device, gomidi.ControlChange(channel, controller, value))
+}
+
+func (r *Recorder) ProgramChange(device int, channel uint8, value uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(device, gomidi.ProgramChange(channel, value))
+}
+
+func (r *Recorder) Pitchbend(device int, channel uint8, value int16) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(device, gomidi.Pitchbend(channel, value))
+}
+
+func (r *Recorder) AfterTouch(device int, channel uint8, value uint8) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(device, gomidi.AfterTouch(channel, value))
+}
+
+func (r *Recorder) SendClock(device int)      {}
+func (r *Recorder) TransportStart(device int) {}
+func (r *Recorder) TransportStop(device int)  {}
+func (r *Recorder) Close()                    {}
+
+// NewDevice returns a recorded device, identified by its name. Devices are
+// created on demand as there is no actual midi device.
+func (r *Recorder) NewDevice(device, fallback string) Device {
+	if device == "" {
+		device = fallback
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := slices.Index(r.devices, device)
+	if id < 0 {
+		id = len(r.devices)
+		r.devices = append(r.devices, device)
+	}
+	return Device{
+		Name: device,
+		ID:   id,
+	}
+}
+
+func (r *Recorder) GetDevice(device int) Device {
+	return Device{
+		Name: r.DeviceName(device),
+		ID:   device,
+	}
+}
+
+func (r *Recorder) record(device int, msg gomidi.Message) {
+	r.events = append(r.events, Event{
+		Position: r.position,
+		Device:   device,
+		Message:  msg,
+	})
+}
+
+func (r *Recorder) noteOff(n recordedNote) {
+	if _, ok := r.playing[n]; !ok {
+		return
+	}
+	delete(r.playing, n)
+	r.record(n.device, gomidi.NoteOff(n.channel, n.key))
+}
+
+// sortedPlaying returns the notes being played in a stable order, so that
+// renders are reproducible.
+func (r *Recorder) sortedPlaying() []recordedNote {
+	notes := make([]recordedNote, 0, len(r.playing))
+	for n := range r.playing {
+		notes = append(notes, n)
+	}
+	slices.SortFunc(notes, func(a, b recordedNote) int {
+		if a.device != b.device {
+			return a.device - b.device
+		}
+		if a.channel != b.channel {
+			return int(a.channel) - int(b.channel)
+		}
+		return int(a.key) - int(b.key)
+	})
+	return notes
+}
@@ -0,0 +1,154 @@
+// Package render plays grids offline, without any real-time clock, and
+// writes the played midi messages to standard midi files.
+package render
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"signls/core/common"
+	"signls/core/field"
+	"signls/core/music"
+	"signls/filesystem"
+	"signls/midi"
+
+	"gitlab.com/gomidi/midi/v2/smf"
+)
+
+const (
+	ticksPerPulse  = 20
+	quartersPerBar = 4
+)
+
+// track identifies a rendered track: each device and channel pair gets its
+// own track.
+type track struct {
+	device  int
+	channel uint8
+}
+
+// tempoChange is a tempo change at a given position, in ticks.
+type tempoChange struct {
+	position uint64
+	tempo    float64
+}
+
+// Render plays a grid of the bank for the given number of bars and returns
+// the played midi messages as a multi-track standard midi file. Grid
+// changes triggered by bank meta commands are followed.
+func Render(bank *filesystem.Bank, index, bars int) (*smf.SMF, error) {
+	if index < 0 || index >= len(bank.Grids) {
+		return nil, fmt.Errorf("grid %d does not exist", index+1)
+	}
+	if bars < 1 {
+		return nil, errors.New("at least one bar must be rendered")
+	}
+	if bank.Grids[index].IsEmpty() {
+		return nil, fmt.Errorf("grid %d is empty", index+1)
+	}
+
+	recorder := midi.NewRecorder()
+	bank.Active = index
+	grid := field.NewOfflineFromBank(index, bank.ActiveGrid(), recorder)
+	tempos := []tempoChange{{tempo: grid.Tempo()}}
+
+	var (
+		pulse     uint64    // Pulse since the grid was loaded, used for swing.
+		position  float64   // Position in pulses since the beginning of the render.
+		positions []float64 // Position of each played pulse.
+	)
+	total := bars * quartersPerBar * common.StepsPerQuarterNote * common.PulsesPerStep
+	// Notes are played with a fixed latency: the grid is played for the
+	// latency on top, and the messages are positioned back on the pulse the
+	// notes were triggered at.
+	for i := range total + music.Latency {
+		positions = append(positions, position)
+		recorder.SetPosition(ticks(positions[max(i-music.Latency, 0)]))
+		swing := grid.Swing()
+		grid.Update()
+		position += common.SwingPosition(pulse+1, swing) - common.SwingPosition(pulse, swing)
+		pulse++
+		if i >= total {
+			continue
+		}
+
+		if tempo := grid.Tempo(); tempo != tempos[len(tempos)-1].tempo {
+			tempos = append(tempos, tempoChange{ticks(position), tempo})
+		}
+		if grid.BankIndex != bank.Active {
+			bank.Active = grid.BankIndex
+			grid.Load(bank.Active, bank.ActiveGrid())
+			pulse = 0
+		}
+	}
+	end := ticks(positions[total])
+	recorder.SetPosition(end)
+	recorder.SilenceAll()
+
+	return newSMF(recorder, tempos, end, fmt.Sprintf("%s %d", bank.Filename(), index+1))
+}
+
+// newSMF creates a standard midi file with a first track holding the
+// tempo changes, followed by a track for each device and channel pair.
+func newSMF(recorder *midi.Recorder, tempos []tempoChange, end uint64, name string) (*smf.SMF, error) {
+	s := smf.New()
+	s.TimeFormat = smf.MetricTicks(ticksPerPulse * common.PulsesPerStep * common.StepsPerQuarterNote)
+
+	var conductor smf.Track
+	conductor.Add(0, smf.MetaTrackSequenceName(name))
+	conductor.Add(0, smf.MetaMeter(quartersPerBar, 4))
+	var last uint64
+	for _, t := range tempos {
+		conductor.Add(uint32(t.position-last), smf.MetaTempo(t.tempo))
+		last = t.position
+	}
+	conductor.Close(uint32(end - last))
+	if err := s.Add(conductor); err != nil {
+		return nil, err
+	}
+
+	events := map[track][]midi.Event{}
+	for _, e := range recorder.Events() {
+		var channel uint8
+		if !e.Message.GetChannel(&channel) {
+			continue
+		}
+		t := track{e.Device, channel}
+		events[t] = append(events[t], e)
+	}
+
+	tracks := make([]track, 0, len(events))
+	for t := range events {
+		tracks = append(tracks, t)
+	}
+	slices.SortFunc(tracks, func(a, b track) int {
+		return cmp.Or(cmp.Compare(a.device, b.device), cmp.Compare(a.channel, b.channel))
+	})
+
+	for _, t := range tracks {
+		var tr smf.Track
+		name := fmt.Sprintf("channel %d", t.channel+1)
+		if device := recorder.DeviceName(t.device); device != "" {
+			name = fmt.Sprintf("%s %s", device, name)
+		}
+		tr.Add(0, smf.MetaTrackSequenceName(name))
+		var last uint64
+		for _, e := range events[t] {
+			tr.Add(uint32(e.Position-last), e.Message)
+			last = e.Position
+		}
+		tr.Close(uint32(end - last))
+		if err := s.Add(tr); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ticks converts a position in pulses to midi file ticks.
+func ticks(position float64) uint64 {
+	return uint64(math.Round(position * ticksPerPulse))
+}
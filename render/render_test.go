@@ -0,0 +1,57 @@
+package render
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"signls/core/common"
+	"signls/core/field"
+	"signls/filesystem"
+	"signls/midi"
+
+	gomidi "gitlab.com/gomidi/midi/v2"
+)
+
+func TestRender(t *testing.T) {
+	bank := filesystem.New(filepath.Join(t.TempDir(), "bank.json"))
+	grid := field.NewOfflineGrid(4, 4, &midi.Mock{}, "")
+	grid.AddNodeFromSymbol("e", 1, 1)
+	grid.Save(bank)
+
+	s, err := Render(bank, 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.NumTracks() != 2 {
+		t.Fatalf("expected a tempo track and a note track, got %d tracks", s.NumTracks())
+	}
+	var (
+		on, off  int
+		position uint64
+	)
+	for _, e := range s.Tracks[1] {
+		position += uint64(e.Delta)
+		msg := gomidi.Message(e.Message)
+		switch {
+		case msg.GetNoteStart(nil, nil, nil):
+			if step := uint64(ticksPerPulse * common.PulsesPerStep); position%step != 0 {
+				t.Errorf("expected notes to start on steps, got a note at tick %d", position)
+			}
+			on++
+		case msg.GetNoteEnd(nil, nil):
+			off++
+		}
+	}
+	if on != 16 || off != 16 {
+		t.Errorf("expected 16 notes on and off, got %d on and %d off", on, off)
+	}
+
+	var first, second bytes.Buffer
+	s.WriteTo(&first)
+	s, _ = Render(bank, 0, 4)
+	s.WriteTo(&second)
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("expected identical renders")
+	}
+}
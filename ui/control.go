@@ -124,6 +124,10 @@ func (m mainModel) bankSelection() string {
 func (m mainModel) gridInfo() string {
 	root := param.Get("root", m.gridParams)
 	scale := param.Get("scale", m.gridParams)
+	tempo := fmt.Sprintf("%.f", m.grid.Tempo())
+	if m.grid.Swing() != common.SwingMin {
+		tempo = fmt.Sprintf("%s %d%%", tempo, m.grid.Swing())
+	}
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		lipgloss.JoinVertical(
@@ -133,7 +137,7 @@ func (m mainModel) gridInfo() string {
 		),
 		lipgloss.JoinVertical(
 			lipgloss.Left,
-			cellStyle.Render(fmt.Sprintf("%.f %s", m.grid.Tempo(), m.tempoSymbol())),
+			cellStyle.Render(fmt.Sprintf("%s %s", tempo, m.tempoSymbol())),
 			cellStyle.Render(fmt.Sprintf("%s %d", m.transportSymbol(), m.grid.Pulse())),
 		),
 		lipgloss.JoinVertical(
@@ -148,6 +152,7 @@ func (m mainModel) gridInfo() string {
 				activeBankStyle.Render(bankGridLabel(m.bank.Active, m.bank.ActiveGrid())),
 				m.bank.Filename(),
 			),
+			m.fillLabel(),
 		),
 	)
 }
@@ -156,11 +161,17 @@ func (m mainModel) paramEdit() string {
 	var params []string
 
 	if len(m.params) > 1 {
+		arrows := pagesArrows[1]
+		if m.paramPage == 0 {
+			arrows = pagesArrows[0]
+		} else if m.paramPage == len(m.params)-1 {
+			arrows = pagesArrows[2]
+		}
 		params = []string{
 			cellStyle.Render(
 				lipgloss.JoinVertical(
 					lipgloss.Left,
-					pagesArrows[m.paramPage]...,
+					arrows...,
 				),
 			),
 		}
@@ -193,8 +204,15 @@ func (m mainModel) tempoSymbol() string {
 	return " "
 }
 
+func (m mainModel) fillLabel() string {
+	if m.grid.Fill() {
+		return activeCellStyle.Render("fill")
+	}
+	return ""
+}
+
 func (m mainModel) transportSymbol() string {
-	if m.grid.Playing {
+	if m.grid.Playing() {
 		return "▶"
 	}
 	return "■"
@@ -213,6 +231,15 @@ func (m mainModel) modeName() string {
 	}
 }
 
+// selectedParams returns the params of the selected emitters.
+func (m mainModel) selectedParams() [][]param.Param {
+	var params [][]param.Param
+	m.grid.Read(func() {
+		params = param.NewParamsForNodes(m.grid, m.selectedEmitters())
+	})
+	return params
+}
+
 func (m mainModel) selectedNode() common.Node {
 	return m.grid.Nodes()[m.cursorY][m.cursorX]
 }
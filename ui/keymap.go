@@ -25,6 +25,11 @@ type keyMap struct {
 	EditDown  key.Binding
 	EditLeft  key.Binding
 
+	EditUpRight   key.Binding
+	EditDownRight key.Binding
+	EditDownLeft  key.Binding
+	EditUpLeft    key.Binding
+
 	EditInput key.Binding
 
 	Bank key.Binding
@@ -38,6 +43,12 @@ type keyMap struct {
 	AddToll   key.Binding
 	AddZone   key.Binding
 	AddHole   key.Binding
+	AddChord  key.Binding
+	AddArp    key.Binding
+
+	AddMirror  key.Binding
+	AddRotator key.Binding
+	AddValve   key.Binding
 
 	Copy  key.Binding
 	Cut   key.Binding
@@ -50,6 +61,8 @@ type keyMap struct {
 	MuteNode    key.Binding
 	MuteAllNode key.Binding
 
+	Fill key.Binding
+
 	RootNoteUp   key.Binding
 	RootNoteDown key.Binding
 	ScaleUp      key.Binding
@@ -76,8 +89,8 @@ func (k keyMap) ShortHelp() []key.Binding {
 // key.Map interface.
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Bank, k.AddBang, k.AddEuclid, k.AddPass, k.AddSpread, k.AddCycle, k.AddDice, k.AddToll, k.AddZone, k.AddHole, k.RootNoteUp, k.RootNoteDown, k.ScaleUp, k.ScaleDown, k.Cancel, k.Configuration, k.FitGridToWindow, k.Help, k.Quit},
-		{k.Play, k.EditNode, k.RemoveNode, k.TriggerNode, k.MuteNode, k.MuteAllNode, k.Copy, k.Cut, k.Paste, k.Up, k.Right, k.Down, k.Left, k.SelectionUp, k.SelectionRight, k.SelectionDown, k.SelectionLeft, k.EditUp, k.EditDown, k.EditRight, k.EditLeft, k.EditInput},
+		{k.Bank, k.AddBang, k.AddEuclid, k.AddPass, k.AddSpread, k.AddCycle, k.AddDice, k.AddToll, k.AddZone, k.AddHole, k.AddChord, k.AddArp, k.AddMirror, k.AddRotator, k.AddValve, k.RootNoteUp, k.RootNoteDown, k.ScaleUp, k.ScaleDown, k.Cancel, k.Configuration, k.FitGridToWindow, k.Help, k.Quit},
+		{k.Play, k.EditNode, k.RemoveNode, k.TriggerNode, k.MuteNode, k.MuteAllNode, k.Fill, k.Copy, k.Cut, k.Paste, k.Up, k.Right, k.Down, k.Left, k.SelectionUp, k.SelectionRight, k.SelectionDown, k.SelectionLeft, k.EditUp, k.EditDown, k.EditRight, k.EditLeft, k.EditUpRight, k.EditDownRight, k.EditDownLeft, k.EditUpLeft, k.EditInput},
 	}
 }
 
@@ -92,6 +105,14 @@ func (k keyMap) Direction(msg tea.KeyMsg) string {
 		return "down"
 	case key.Matches(msg, k.Left, k.SelectionLeft, k.EditLeft):
 		return "left"
+	case key.Matches(msg, k.EditUpRight):
+		return "up-right"
+	case key.Matches(msg, k.EditDownRight):
+		return "down-right"
+	case key.Matches(msg, k.EditDownLeft):
+		return "down-left"
+	case key.Matches(msg, k.EditUpLeft):
+		return "up-left"
 	default:
 		return ""
 	}
@@ -118,6 +139,16 @@ func (k keyMap) EmitterSymbol(msg tea.KeyMsg) string {
 		return "z"
 	case key.Matches(msg, k.AddHole):
 		return "h"
+	case key.Matches(msg, k.AddChord):
+		return "k"
+	case key.Matches(msg, k.AddArp):
+		return "a"
+	case key.Matches(msg, k.AddMirror):
+		return "m"
+	case key.Matches(msg, k.AddRotator):
+		return "r"
+	case key.Matches(msg, k.AddValve):
+		return "v"
 	default:
 		return ""
 	}
@@ -178,6 +209,22 @@ func newKeyMap(keys filesystem.KeyMap) keyMap {
 			key.WithKeys(keys.EditLeft),
 			key.WithHelp(keys.EditLeft, "decrease parameter mode value"),
 		),
+		EditUpRight: key.NewBinding(
+			key.WithKeys(keys.EditUpRight),
+			key.WithHelp(keys.EditUpRight, "modify selected node up-right direction"),
+		),
+		EditDownRight: key.NewBinding(
+			key.WithKeys(keys.EditDownRight),
+			key.WithHelp(keys.EditDownRight, "modify selected node down-right direction"),
+		),
+		EditDownLeft: key.NewBinding(
+			key.WithKeys(keys.EditDownLeft),
+			key.WithHelp(keys.EditDownLeft, "modify selected node down-left direction"),
+		),
+		EditUpLeft: key.NewBinding(
+			key.WithKeys(keys.EditUpLeft),
+			key.WithHelp(keys.EditUpLeft, "modify selected node up-left direction"),
+		),
 		EditInput: key.NewBinding(
 			key.WithKeys(keys.EditInput),
 			key.WithHelp(keys.EditInput, "modify parameter"),
@@ -222,6 +269,26 @@ func newKeyMap(keys filesystem.KeyMap) keyMap {
 			key.WithKeys(keys.AddHole),
 			key.WithHelp(keys.AddHole, "add pass emitter"),
 		),
+		AddChord: key.NewBinding(
+			key.WithKeys(keys.AddChord),
+			key.WithHelp(keys.AddChord, "add chord emitter"),
+		),
+		AddArp: key.NewBinding(
+			key.WithKeys(keys.AddArp),
+			key.WithHelp(keys.AddArp, "add arpeggiator"),
+		),
+		AddMirror: key.NewBinding(
+			key.WithKeys(keys.AddMirror),
+			key.WithHelp(keys.AddMirror, "add mirror"),
+		),
+		AddRotator: key.NewBinding(
+			key.WithKeys(keys.AddRotator),
+			key.WithHelp(keys.AddRotator, "add rotator"),
+		),
+		AddValve: key.NewBinding(
+			key.WithKeys(keys.AddValve),
+			key.WithHelp(keys.AddValve, "add valve"),
+		),
 		Copy: key.NewBinding(
 			key.WithKeys(keys.Copy),
 			key.WithHelp(keys.Copy, "copy node | bank"),
@@ -254,6 +321,10 @@ func newKeyMap(keys filesystem.KeyMap) keyMap {
 			key.WithKeys(keys.MuteAllNode),
 			key.WithHelp(keys.MuteAllNode, "mute/unmute all selected nodes"),
 		),
+		Fill: key.NewBinding(
+			key.WithKeys(keys.Fill),
+			key.WithHelp(keys.Fill, "toggle fill mode"),
+		),
 		RootNoteUp: key.NewBinding(
 			key.WithKeys(keys.RootNoteUp),
 			key.WithHelp(keys.RootNoteUp, "increase root note"),
@@ -103,7 +103,7 @@ func (m mainModel) renderNode(n common.Node, x, y int) string {
 				Background(lipgloss.Color(n.Color())).
 				Render(symbol)
 		}
-	case *node.HoleEmitter:
+	case *node.HoleEmitter, common.Router:
 		symbol := n.Symbol()
 
 		if isCursor && m.mode != EDIT {
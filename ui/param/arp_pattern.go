@@ -0,0 +1,65 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type ArpPattern struct {
+	nodes []common.Node
+}
+
+func (a ArpPattern) Name() string {
+	return "arp"
+}
+
+func (a ArpPattern) Help() string {
+	return ""
+}
+
+func (a ArpPattern) Display() string {
+	return node.ArpPattern(a.Value()).Name()
+}
+
+func (a ArpPattern) Value() int {
+	return a.nodes[0].(*node.ArpEmitter).Pattern.Value()
+}
+
+func (a ArpPattern) AltValue() int {
+	return 0
+}
+
+func (a ArpPattern) Up() {
+	a.Set(a.Value() + 1)
+}
+
+func (a ArpPattern) Down() {
+	a.Set(a.Value() - 1)
+}
+
+func (a ArpPattern) Left() {}
+
+func (a ArpPattern) Right() {}
+
+func (a ArpPattern) AltUp() {}
+
+func (a ArpPattern) AltDown() {}
+
+func (a ArpPattern) AltLeft() {}
+
+func (a ArpPattern) AltRight() {}
+
+func (a ArpPattern) Set(value int) {
+	if value < int(node.ARP_UP) {
+		value = int(node.MaxArpPattern)
+	} else if value > int(node.MaxArpPattern) {
+		value = int(node.ARP_UP)
+	}
+	for _, n := range a.nodes {
+		n.(*node.ArpEmitter).Pattern.Set(value)
+	}
+}
+
+func (a ArpPattern) SetAlt(value int) {}
+
+func (a ArpPattern) SetEditValue(input string) {}
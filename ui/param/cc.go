@@ -50,6 +50,13 @@ func (c CC) Display() string {
 	if c.nodes[0].(music.Audible).Note().Controls[c.index].Type == music.SilentControlType {
 		return "⨯"
 	}
+	if sequence := c.nodes[0].(music.Audible).Note().Controls[c.index].Value.Sequence(); sequence.Len() > 0 {
+		values := make([]string, sequence.Len())
+		for i, value := range sequence.Values() {
+			values[i] = fmt.Sprintf("%d", value)
+		}
+		return displaySequence(sequence.Mode(), values)
+	}
 	if c.nodes[0].(music.Audible).Note().Controls[c.index].Value.RandomAmount() != 0 {
 		return util.Normalize(
 			fmt.Sprintf(
@@ -127,9 +134,19 @@ func (c CC) SetController(value uint8) {
 }
 
 func (c CC) SetEditValue(input string) {
+	if values, mode, ok := parseSequence(input, strconv.Atoi); ok {
+		for _, n := range c.nodes {
+			value := n.(music.Audible).Note().Controls[c.index].Value
+			value.SetSequence(sequenceOf(values, value.Min(), value.Max()), mode)
+		}
+		return
+	}
 	value, err := strconv.Atoi(input)
 	if err != nil {
 		return
 	}
 	c.Set(value)
+	for _, n := range c.nodes {
+		n.(music.Audible).Note().Controls[c.index].Value.Sequence().Clear()
+	}
 }
@@ -18,10 +18,18 @@ func (c Channel) Name() string {
 }
 
 func (c Channel) Help() string {
-	return ""
+	value := c.nodes[0].(music.Audible).Note().Channel
+	return valueHelp(value.Sequence(), value.RandomAmount(), value.Distribution())
 }
 
 func (c Channel) Display() string {
+	if sequence := c.nodes[0].(music.Audible).Note().Channel.Sequence(); sequence.Len() > 0 {
+		values := make([]string, sequence.Len())
+		for i, value := range sequence.Values() {
+			values[i] = fmt.Sprintf("%d", value+1)
+		}
+		return displaySequence(sequence.Mode(), values)
+	}
 	if c.nodes[0].(music.Audible).Note().Channel.RandomAmount() != 0 {
 		return util.Normalize(
 			fmt.Sprintf(
@@ -58,9 +66,13 @@ func (c Channel) Right() {
 	c.SetAlt(c.nodes[0].(music.Audible).Note().Channel.RandomAmount() + 1)
 }
 
-func (c Channel) AltUp() {}
+func (c Channel) AltUp() {
+	c.setDistribution(c.nodes[0].(music.Audible).Note().Channel.Distribution() + 1)
+}
 
-func (c Channel) AltDown() {}
+func (c Channel) AltDown() {
+	c.setDistribution(c.nodes[0].(music.Audible).Note().Channel.Distribution() - 1)
+}
 
 func (c Channel) AltLeft() {}
 
@@ -79,9 +91,28 @@ func (c Channel) SetAlt(value int) {
 }
 
 func (c Channel) SetEditValue(input string) {
+	if values, mode, ok := parseSequence(input, strconv.Atoi); ok {
+		for i := range values {
+			values[i]--
+		}
+		for _, n := range c.nodes {
+			channel := n.(music.Audible).Note().Channel
+			channel.SetSequence(sequenceOf(values, channel.Min(), channel.Max()), mode)
+		}
+		return
+	}
 	value, err := strconv.Atoi(input)
 	if err != nil {
 		return
 	}
 	c.Set(value - 1)
+	for _, n := range c.nodes {
+		n.(music.Audible).Note().Channel.Sequence().Clear()
+	}
+}
+
+func (c Channel) setDistribution(distribution common.Distribution) {
+	for _, n := range c.nodes {
+		n.(music.Audible).Note().Channel.SetDistribution(distribution)
+	}
 }
@@ -0,0 +1,91 @@
+package param
+
+import (
+	"slices"
+
+	"signls/core/common"
+	"signls/core/music"
+	"signls/core/theory"
+)
+
+type Chord struct {
+	nodes []common.Node
+}
+
+func (c Chord) Name() string {
+	return "chord"
+}
+
+func (c Chord) Help() string {
+	return "type degrees for a custom chord"
+}
+
+func (c Chord) Display() string {
+	return theory.Chord(nodeChord(c.nodes[0]).Type.Value()).Name()
+}
+
+func (c Chord) Value() int {
+	return nodeChord(c.nodes[0]).Type.Value()
+}
+
+func (c Chord) AltValue() int {
+	return 0
+}
+
+func (c Chord) Up() {
+	c.Set(slices.Index(theory.AllChords(), theory.Chord(c.Value())) + 1)
+}
+
+func (c Chord) Down() {
+	index := slices.Index(theory.AllChords(), theory.Chord(c.Value()))
+	if index < 0 {
+		index = len(theory.AllChords())
+	}
+	c.Set(index - 1)
+}
+
+func (c Chord) Left() {}
+
+func (c Chord) Right() {}
+
+func (c Chord) AltUp() {}
+
+func (c Chord) AltDown() {}
+
+func (c Chord) AltLeft() {}
+
+func (c Chord) AltRight() {}
+
+func (c Chord) Set(value int) {
+	chords := theory.AllChords()
+	if value < 0 {
+		value = len(chords) - 1
+	} else if value >= len(chords) {
+		value = 0
+	}
+	c.setType(chords[value])
+}
+
+func (c Chord) SetAlt(value int) {}
+
+func (c Chord) SetEditValue(input string) {
+	chord, err := theory.ParseChord(input)
+	if err != nil {
+		return
+	}
+	c.setType(chord)
+}
+
+func (c Chord) setType(chord theory.Chord) {
+	for _, n := range c.nodes {
+		nodeChord(n).Type.Set(int(chord))
+	}
+}
+
+// nodeChord returns the chord played by a chord emitter or an arpeggiator.
+func nodeChord(n common.Node) *music.Chord {
+	if b, ok := n.(common.Behavioral); ok {
+		return b.Behavior().(music.Chorded).Chord()
+	}
+	return n.(music.Chorded).Chord()
+}
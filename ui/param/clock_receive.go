@@ -0,0 +1,61 @@
+package param
+
+import (
+	"signls/core/field"
+)
+
+type ClockReceive struct {
+	grid *field.Grid
+}
+
+func (c ClockReceive) Name() string {
+	return "sync"
+}
+
+func (c ClockReceive) Help() string {
+	if c.grid.ExternalClock() {
+		return "follow input device clock"
+	}
+	return "internal clock"
+}
+
+func (c ClockReceive) Display() string {
+	if c.grid.ExternalClock() {
+		return "ext"
+	}
+	return "int"
+}
+
+func (c ClockReceive) Value() int {
+	return 0
+}
+
+func (c ClockReceive) AltValue() int {
+	return 0
+}
+
+func (c ClockReceive) Up() {
+	c.grid.SetExternalClock(true)
+}
+
+func (c ClockReceive) Down() {
+	c.grid.SetExternalClock(false)
+}
+
+func (c ClockReceive) Left() {}
+
+func (c ClockReceive) Right() {}
+
+func (c ClockReceive) AltUp() {}
+
+func (c ClockReceive) AltDown() {}
+
+func (c ClockReceive) AltLeft() {}
+
+func (c ClockReceive) AltRight() {}
+
+func (c ClockReceive) Set(value int) {}
+
+func (c ClockReceive) SetAlt(value int) {}
+
+func (c ClockReceive) SetEditValue(input string) {}
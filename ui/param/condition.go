@@ -0,0 +1,80 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/music"
+)
+
+type Condition struct {
+	nodes []common.Node
+}
+
+func (c Condition) Name() string {
+	return "cnd"
+}
+
+func (c Condition) Help() string {
+	return c.nodes[0].(music.Audible).Note().Condition.Help()
+}
+
+func (c Condition) Display() string {
+	return c.nodes[0].(music.Audible).Note().Condition.Name()
+}
+
+func (c Condition) Value() int {
+	condition := c.nodes[0].(music.Audible).Note().Condition
+	for i, cond := range music.AllConditions() {
+		if cond.Equal(condition) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (c Condition) AltValue() int {
+	return 0
+}
+
+func (c Condition) Up() {
+	c.Set(c.Value() + 1)
+}
+
+func (c Condition) Down() {
+	c.Set(c.Value() - 1)
+}
+
+func (c Condition) Left() {}
+
+func (c Condition) Right() {}
+
+func (c Condition) AltUp() {}
+
+func (c Condition) AltDown() {}
+
+func (c Condition) AltLeft() {}
+
+func (c Condition) AltRight() {}
+
+func (c Condition) Set(value int) {
+	conditions := music.AllConditions()
+	if value < 0 || value >= len(conditions) {
+		return
+	}
+	c.set(conditions[value])
+}
+
+func (c Condition) SetAlt(value int) {}
+
+func (c Condition) SetEditValue(input string) {
+	condition, err := music.ParseCondition(input)
+	if err != nil {
+		return
+	}
+	c.set(condition)
+}
+
+func (c Condition) set(condition music.Condition) {
+	for _, n := range c.nodes {
+		n.(music.Audible).Note().Condition = condition
+	}
+}
@@ -69,6 +69,14 @@ func (d Direction) SetFromKeyString(key string) {
 		dir = common.DOWN
 	case "left":
 		dir = common.LEFT
+	case "up-right":
+		dir = common.UPRIGHT
+	case "down-right":
+		dir = common.DOWNRIGHT
+	case "down-left":
+		dir = common.DOWNLEFT
+	case "up-left":
+		dir = common.UPLEFT
 	default:
 		dir = common.UP
 	}
@@ -0,0 +1,74 @@
+package param
+
+import (
+	"signls/core/field"
+)
+
+type Edge struct {
+	grid *field.Grid
+}
+
+func (e Edge) Name() string {
+	return "edges"
+}
+
+func (e Edge) Help() string {
+	switch e.grid.Edge {
+	case field.WRAP:
+		return "signals reappear on the opposite edge"
+	case field.BOUNCE:
+		return "signals reverse their direction"
+	default:
+		return "signals disappear"
+	}
+}
+
+func (e Edge) Display() string {
+	return e.grid.Edge.Name()
+}
+
+func (e Edge) Value() int {
+	return int(e.grid.Edge)
+}
+
+func (e Edge) AltValue() int {
+	return 0
+}
+
+func (e Edge) Up() {
+	e.Set(e.Value() + 1)
+}
+
+func (e Edge) Down() {
+	e.Set(e.Value() - 1)
+}
+
+func (e Edge) Left() {}
+
+func (e Edge) Right() {}
+
+func (e Edge) AltUp() {}
+
+func (e Edge) AltDown() {}
+
+func (e Edge) AltLeft() {}
+
+func (e Edge) AltRight() {}
+
+func (e Edge) Set(value int) {
+	if value < int(field.KILL) || value > int(field.MaxEdgeMode) {
+		return
+	}
+	e.grid.Edge = field.EdgeMode(value)
+}
+
+func (e Edge) SetAlt(value int) {}
+
+func (e Edge) SetEditValue(input string) {
+	for mode := field.KILL; mode <= field.MaxEdgeMode; mode++ {
+		if mode.Name() == input {
+			e.Set(int(mode))
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type EmitEach struct {
+	nodes []common.Node
+}
+
+func (e EmitEach) Name() string {
+	return "emit"
+}
+
+func (e EmitEach) Help() string {
+	if e.nodes[0].(*node.ArpEmitter).EmitEach {
+		return "emit on each key"
+	}
+	return "emit on first key"
+}
+
+func (e EmitEach) Display() string {
+	if e.nodes[0].(*node.ArpEmitter).EmitEach {
+		return "each"
+	}
+	return "first"
+}
+
+func (e EmitEach) Value() int {
+	if e.nodes[0].(*node.ArpEmitter).EmitEach {
+		return 1
+	}
+	return 0
+}
+
+func (e EmitEach) AltValue() int {
+	return 0
+}
+
+func (e EmitEach) Up() {
+	e.Set(1)
+}
+
+func (e EmitEach) Down() {
+	e.Set(0)
+}
+
+func (e EmitEach) Left() {}
+
+func (e EmitEach) Right() {}
+
+func (e EmitEach) AltUp() {}
+
+func (e EmitEach) AltDown() {}
+
+func (e EmitEach) AltLeft() {}
+
+func (e EmitEach) AltRight() {}
+
+func (e EmitEach) Set(value int) {
+	for _, n := range e.nodes {
+		n.(*node.ArpEmitter).EmitEach = value != 0
+	}
+}
+
+func (e EmitEach) SetAlt(value int) {}
+
+func (e EmitEach) SetEditValue(input string) {}
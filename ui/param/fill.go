@@ -0,0 +1,70 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/music"
+)
+
+type Fill struct {
+	nodes []common.Node
+}
+
+func (f Fill) Name() string {
+	return "fill"
+}
+
+func (f Fill) Help() string {
+	switch f.nodes[0].(music.Audible).Note().Fill {
+	case music.InFillMode:
+		return "plays and emits in fill mode only"
+	case music.OutOfFillMode:
+		return "plays and emits out of fill mode only"
+	default:
+		return ""
+	}
+}
+
+func (f Fill) Display() string {
+	return f.nodes[0].(music.Audible).Note().Fill.Name()
+}
+
+func (f Fill) Value() int {
+	return int(f.nodes[0].(music.Audible).Note().Fill)
+}
+
+func (f Fill) AltValue() int {
+	return 0
+}
+
+func (f Fill) Up() {
+	f.Set(f.Value() + 1)
+}
+
+func (f Fill) Down() {
+	f.Set(f.Value() - 1)
+}
+
+func (f Fill) Left() {}
+
+func (f Fill) Right() {}
+
+func (f Fill) AltUp() {}
+
+func (f Fill) AltDown() {}
+
+func (f Fill) AltLeft() {}
+
+func (f Fill) AltRight() {}
+
+func (f Fill) Set(value int) {
+	if value < int(music.AnyFillMode) || value > int(music.OutOfFillMode) {
+		return
+	}
+	for _, n := range f.nodes {
+		n.(music.Audible).Note().Fill = music.FillMode(value)
+	}
+}
+
+func (f Fill) SetAlt(value int) {}
+
+func (f Fill) SetEditValue(input string) {}
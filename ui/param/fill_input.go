@@ -0,0 +1,72 @@
+package param
+
+import (
+	"fmt"
+
+	"signls/core/field"
+)
+
+const (
+	maxFillChannel = 16
+)
+
+type FillInput struct {
+	grid *field.Grid
+}
+
+func (f FillInput) Name() string {
+	return "fill"
+}
+
+func (f FillInput) Help() string {
+	if f.grid.FillChannel == 0 {
+		return ""
+	}
+	return fmt.Sprintf("notes held on input channel %d hold fill mode", f.grid.FillChannel)
+}
+
+func (f FillInput) Display() string {
+	if f.grid.FillChannel == 0 {
+		return "⨯"
+	}
+	return fmt.Sprintf("%d", f.grid.FillChannel)
+}
+
+func (f FillInput) Value() int {
+	return int(f.grid.FillChannel)
+}
+
+func (f FillInput) AltValue() int {
+	return 0
+}
+
+func (f FillInput) Up() {
+	f.Set(f.Value() + 1)
+}
+
+func (f FillInput) Down() {
+	f.Set(f.Value() - 1)
+}
+
+func (f FillInput) Left() {}
+
+func (f FillInput) Right() {}
+
+func (f FillInput) AltUp() {}
+
+func (f FillInput) AltDown() {}
+
+func (f FillInput) AltLeft() {}
+
+func (f FillInput) AltRight() {}
+
+func (f FillInput) Set(value int) {
+	if value < 0 || value > maxFillChannel {
+		return
+	}
+	f.grid.FillChannel = uint8(value)
+}
+
+func (f FillInput) SetAlt(value int) {}
+
+func (f FillInput) SetEditValue(input string) {}
@@ -0,0 +1,76 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type Gate struct {
+	nodes []common.Node
+}
+
+func (g Gate) Name() string {
+	return "gate"
+}
+
+func (g Gate) Help() string {
+	return "key length, in percent of a step"
+}
+
+func (g Gate) Display() string {
+	return fmt.Sprintf("%d%%", g.Value())
+}
+
+func (g Gate) Value() int {
+	return g.nodes[0].(*node.ArpEmitter).Gate.Value()
+}
+
+func (g Gate) AltValue() int {
+	return 0
+}
+
+func (g Gate) Up() {
+	g.Set(g.Value() + 1)
+}
+
+func (g Gate) Down() {
+	g.Set(g.Value() - 1)
+}
+
+func (g Gate) Left() {
+	g.Set(g.Value() - 10)
+}
+
+func (g Gate) Right() {
+	g.Set(g.Value() + 10)
+}
+
+func (g Gate) AltUp() {}
+
+func (g Gate) AltDown() {}
+
+func (g Gate) AltLeft() {}
+
+func (g Gate) AltRight() {}
+
+func (g Gate) Set(value int) {
+	if value < node.MinArpGate || value > node.MaxArpGate {
+		return
+	}
+	for _, n := range g.nodes {
+		n.(*node.ArpEmitter).Gate.Set(value)
+	}
+}
+
+func (g Gate) SetAlt(value int) {}
+
+func (g Gate) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	g.Set(value)
+}
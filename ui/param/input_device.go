@@ -0,0 +1,67 @@
+package param
+
+import (
+	"fmt"
+
+	"signls/core/field"
+)
+
+type InputDevice struct {
+	grid *field.Grid
+}
+
+func (d InputDevice) Name() string {
+	return "input"
+}
+
+func (d InputDevice) Help() string {
+	if !d.grid.InputDevice().Enabled() {
+		return ""
+	} else if d.grid.InputDevice().Fallback {
+		return fmt.Sprintf("disconnected: %s", d.grid.InputDevice().Name)
+	}
+	return d.grid.InputDevice().Name
+}
+
+func (d InputDevice) Display() string {
+	if !d.grid.InputDevice().Enabled() {
+		return "⨯"
+	} else if d.grid.InputDevice().Fallback {
+		return "??"
+	}
+	return fmt.Sprintf("%d", d.grid.InputDevice().ID)
+}
+
+func (d InputDevice) Value() int {
+	return d.grid.InputDevice().ID
+}
+
+func (d InputDevice) AltValue() int {
+	return 0
+}
+
+func (d InputDevice) Up() {
+	d.grid.SetInputDevice(d.grid.Midi().GetInput(d.Value() + 1))
+}
+
+func (d InputDevice) Down() {
+	d.grid.SetInputDevice(d.grid.Midi().GetInput(d.Value() - 1))
+}
+
+func (d InputDevice) Left() {}
+
+func (d InputDevice) Right() {}
+
+func (d InputDevice) AltUp() {}
+
+func (d InputDevice) AltDown() {}
+
+func (d InputDevice) AltLeft() {}
+
+func (d InputDevice) AltRight() {}
+
+func (d InputDevice) Set(value int) {}
+
+func (d InputDevice) SetAlt(value int) {}
+
+func (d InputDevice) SetEditValue(input string) {}
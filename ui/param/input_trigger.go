@@ -0,0 +1,136 @@
+package param
+
+import (
+	"fmt"
+
+	"signls/core/common"
+	"signls/core/music"
+	"signls/core/theory"
+	"signls/ui/util"
+)
+
+type InputTriggerMode uint8
+
+const (
+	InputTriggerModeOff InputTriggerMode = iota
+	InputTriggerModeOn
+	InputTriggerModeVelocity
+)
+
+var inputTriggerModes = []InputTriggerMode{
+	InputTriggerModeOff,
+	InputTriggerModeOn,
+	InputTriggerModeVelocity,
+}
+
+type InputTrigger struct {
+	nodes []common.Node
+}
+
+func (i InputTrigger) Name() string {
+	return "in"
+}
+
+func (i InputTrigger) Help() string {
+	switch i.mode() {
+	case InputTriggerModeOn:
+		return fmt.Sprintf("triggered by input note on channel %s", i.input().DisplayChannel())
+	case InputTriggerModeVelocity:
+		return fmt.Sprintf("triggered by input note on channel %s, with input velocity", i.input().DisplayChannel())
+	default:
+		return ""
+	}
+}
+
+func (i InputTrigger) Display() string {
+	switch i.mode() {
+	case InputTriggerModeOn:
+		return i.input().Key.Name()
+	case InputTriggerModeVelocity:
+		return util.Normalize(fmt.Sprintf("%s\u0332", i.input().Key.Name()))
+	default:
+		return "⨯"
+	}
+}
+
+func (i InputTrigger) input() *music.InputTrigger {
+	return &i.nodes[0].(music.Audible).Note().Input
+}
+
+func (i InputTrigger) mode() InputTriggerMode {
+	if !i.input().Active {
+		return InputTriggerModeOff
+	} else if i.input().Velocity {
+		return InputTriggerModeVelocity
+	}
+	return InputTriggerModeOn
+}
+
+func (i InputTrigger) Value() int {
+	return int(i.input().Key)
+}
+
+func (i InputTrigger) AltValue() int {
+	return int(i.input().Channel)
+}
+
+func (i InputTrigger) Up() {
+	i.Set(i.Value() + 1)
+}
+
+func (i InputTrigger) Down() {
+	i.Set(i.Value() - 1)
+}
+
+func (i InputTrigger) Left() {
+	i.SetAlt(i.AltValue() - 1)
+}
+
+func (i InputTrigger) Right() {
+	i.SetAlt(i.AltValue() + 1)
+}
+
+func (i InputTrigger) AltUp() {}
+
+func (i InputTrigger) AltDown() {}
+
+func (i InputTrigger) AltLeft() {
+	i.setMode(inputTriggerModes[util.Mod(int(i.mode())-1, len(inputTriggerModes))])
+}
+
+func (i InputTrigger) AltRight() {
+	i.setMode(inputTriggerModes[util.Mod(int(i.mode())+1, len(inputTriggerModes))])
+}
+
+func (i InputTrigger) setMode(mode InputTriggerMode) {
+	for _, n := range i.nodes {
+		n.(music.Audible).Note().Input.Active = mode != InputTriggerModeOff
+		n.(music.Audible).Note().Input.Velocity = mode == InputTriggerModeVelocity
+	}
+}
+
+func (i InputTrigger) Set(value int) {
+	if value < 0 {
+		return
+	}
+	for _, n := range i.nodes {
+		n.(music.Audible).Note().Input.SetKey(theory.Key(value))
+	}
+}
+
+func (i InputTrigger) SetAlt(value int) {
+	if value < 0 {
+		return
+	}
+	for _, n := range i.nodes {
+		n.(music.Audible).Note().Input.SetChannel(uint8(value))
+	}
+}
+
+func (i InputTrigger) SetEditValue(input string) {
+	key, err := music.ConvertNoteToMIDI(input)
+	if err != nil {
+		return
+	}
+	i.Set(key)
+}
@@ -0,0 +1,72 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/theory"
+)
+
+type Inversion struct {
+	nodes []common.Node
+}
+
+func (i Inversion) Name() string {
+	return "inv"
+}
+
+func (i Inversion) Help() string {
+	return ""
+}
+
+func (i Inversion) Display() string {
+	return fmt.Sprintf("%d", i.Value())
+}
+
+func (i Inversion) Value() int {
+	return nodeChord(i.nodes[0]).Inversion.Value()
+}
+
+func (i Inversion) AltValue() int {
+	return 0
+}
+
+func (i Inversion) Up() {
+	i.Set(i.Value() + 1)
+}
+
+func (i Inversion) Down() {
+	i.Set(i.Value() - 1)
+}
+
+func (i Inversion) Left() {}
+
+func (i Inversion) Right() {}
+
+func (i Inversion) AltUp() {}
+
+func (i Inversion) AltDown() {}
+
+func (i Inversion) AltLeft() {}
+
+func (i Inversion) AltRight() {}
+
+func (i Inversion) Set(value int) {
+	if value < 0 || value >= len(theory.Chord(nodeChord(i.nodes[0]).Type.Value()).Degrees()) {
+		return
+	}
+	for _, n := range i.nodes {
+		nodeChord(n).Inversion.Set(value)
+	}
+}
+
+func (i Inversion) SetAlt(value int) {}
+
+func (i Inversion) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	i.Set(value)
+}
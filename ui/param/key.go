@@ -31,7 +31,8 @@ func (k *Key) Name() string {
 }
 
 func (k *Key) Help() string {
-	return ""
+	value := k.nodes[0].(music.Audible).Note().Key
+	return valueHelp(value.Sequence(), value.RandomAmount(), value.Distribution())
 }
 
 func (k *Key) Display() string {
@@ -39,6 +40,15 @@ func (k *Key) Display() string {
 		return "⨯"
 	}
 
+	if sequence := k.nodes[0].(music.Audible).Note().Key.Sequence(); sequence.Len() > 0 {
+		keys := k.nodes[0].(music.Audible).Note().Key.SequenceKeys(k.root)
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = key.Name()
+		}
+		return displaySequence(sequence.Mode(), values)
+	}
+
 	if k.nodes[0].(music.Audible).Note().Key.RandomAmount() != 0 {
 		return util.Normalize(
 			fmt.Sprintf(
@@ -80,9 +90,13 @@ func (k *Key) Right() {
 	k.SetAlt(k.AltValue() + 1)
 }
 
-func (k *Key) AltUp() {}
+func (k *Key) AltUp() {
+	k.setDistribution(k.nodes[0].(music.Audible).Note().Key.Distribution() + 1)
+}
 
-func (k *Key) AltDown() {}
+func (k *Key) AltDown() {
+	k.setDistribution(k.nodes[0].(music.Audible).Note().Key.Distribution() - 1)
+}
 
 func (k *Key) AltLeft() {
 	k.mode = (k.mode - 1) % 2
@@ -140,6 +154,16 @@ func (k *Key) keyIndex() int {
 }
 
 func (k *Key) SetEditValue(input string) {
+	if values, mode, ok := parseSequence(input, music.ConvertNoteToMIDI); ok {
+		keys := make([]theory.Key, len(values))
+		for i, v := range values {
+			keys[i] = theory.Key(v)
+		}
+		for _, n := range k.nodes {
+			n.(music.Audible).Note().Key.SetSequence(keys, mode, k.root)
+		}
+		return
+	}
 	midiKey, err := music.ConvertNoteToMIDI(input)
 	if err != nil {
 		return
@@ -148,5 +172,12 @@ func (k *Key) SetEditValue(input string) {
 	for _, n := range k.nodes {
 		n.(music.Audible).Note().SetKey(key, k.root)
 		n.(music.Audible).Note().Transpose(k.root, k.scale)
+		n.(music.Audible).Note().Key.Sequence().Clear()
+	}
+}
+
+func (k *Key) setDistribution(distribution common.Distribution) {
+	for _, n := range k.nodes {
+		n.(music.Audible).Note().Key.SetDistribution(distribution)
 	}
 }
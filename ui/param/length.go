@@ -1,17 +1,20 @@
 package param
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"signls/core/common"
 	"signls/core/music"
 	"signls/ui/util"
 )
 
-const (
-	maxLength = 127
-)
+// lengthBars are the lengths in bars reached with up and down, longer
+// lengths than a bar being rarely needed with a finer resolution.
+var lengthBars = []int{1, 2, 3, 4, 6, 8, 12, 16}
 
 type Length struct {
 	nodes []common.Node
@@ -22,45 +25,43 @@ func (l Length) Name() string {
 }
 
 func (l Length) Help() string {
-	return ""
+	value := l.nodes[0].(music.Audible).Note().Length
+	help := valueHelp(value.Sequence(), value.RandomAmount(), value.Distribution())
+	if !l.nodes[0].(music.Audible).Note().Legato {
+		return help
+	} else if help == "" {
+		return "legato"
+	}
+	return "legato, " + help
 }
 
 func (l Length) Display() string {
-	length := int(l.nodes[0].(music.Audible).Note().Length.Value())
-	pulsesPerStep, stepsPerQuarterNote := l.nodes[0].(music.Audible).Note().ClockDivision()
+	note := l.nodes[0].(music.Audible).Note()
 	var display string
-	switch length {
-	case pulsesPerStep / 4:
-		display = "1|64"
-	case pulsesPerStep / 2:
-		display = "1|32"
-	case pulsesPerStep:
-		display = "1|16"
-	case pulsesPerStep * stepsPerQuarterNote / 2:
-		display = "1|8"
-	case pulsesPerStep * stepsPerQuarterNote:
-		display = "1|4"
-	case pulsesPerStep * stepsPerQuarterNote * 2:
-		display = "1|2"
-	case maxLength:
-		display = "inf"
-	default:
-		display = fmt.Sprintf("%.1f", float64(length)/float64(pulsesPerStep))
-	}
-	if l.nodes[0].(music.Audible).Note().Length.RandomAmount() != 0 {
-		return util.Normalize(
-			fmt.Sprintf(
-				"%s%+.1f\u033c",
-				display,
-				float64(l.nodes[0].(music.Audible).Note().Length.RandomAmount())/float64(pulsesPerStep),
-			),
+	if sequence := note.Length.Sequence(); sequence.Len() > 0 {
+		values := make([]string, sequence.Len())
+		for i, value := range sequence.Values() {
+			values[i] = lengthName(value)
+		}
+		display = displaySequence(sequence.Mode(), values)
+	} else {
+		display = lengthName(note.Length.Value())
+	}
+	if note.Length.RandomAmount() != 0 {
+		display = fmt.Sprintf(
+			"%s%+.1f\u033c",
+			display,
+			float64(note.Length.RandomAmount())/float64(common.PulsesPerStep),
 		)
 	}
-	return display
+	if note.Legato {
+		display = fmt.Sprintf("%s\u0332", display)
+	}
+	return util.Normalize(display)
 }
 
 func (l Length) Value() int {
-	return int(l.nodes[0].(music.Audible).Note().Length.Value())
+	return l.nodes[0].(music.Audible).Note().Length.Value()
 }
 
 func (l Length) AltValue() int {
@@ -68,11 +69,19 @@ func (l Length) AltValue() int {
 }
 
 func (l Length) Up() {
-	l.Set(l.Value() + 1)
+	lengths := musicalLengths()
+	i, _ := slices.BinarySearch(lengths, l.Value()+1)
+	if i < len(lengths) {
+		l.Set(lengths[i])
+	}
 }
 
 func (l Length) Down() {
-	l.Set(l.Value() - 1)
+	lengths := musicalLengths()
+	i, _ := slices.BinarySearch(lengths, l.Value())
+	if i > 0 {
+		l.Set(lengths[i-1])
+	}
 }
 
 func (l Length) Left() {
@@ -83,17 +92,25 @@ func (l Length) Right() {
 	l.SetAlt(l.nodes[0].(music.Audible).Note().Length.RandomAmount() + 1)
 }
 
-func (l Length) AltUp() {}
+func (l Length) AltUp() {
+	l.setDistribution(l.nodes[0].(music.Audible).Note().Length.Distribution() + 1)
+}
 
-func (l Length) AltDown() {}
+func (l Length) AltDown() {
+	l.setDistribution(l.nodes[0].(music.Audible).Note().Length.Distribution() - 1)
+}
 
-func (l Length) AltLeft() {}
+func (l Length) AltLeft() {
+	l.toggleLegato()
+}
 
-func (l Length) AltRight() {}
+func (l Length) AltRight() {
+	l.toggleLegato()
+}
 
 func (l Length) Set(value int) {
 	for _, n := range l.nodes {
-		n.(music.Audible).Note().SetLength(uint8(value))
+		n.(music.Audible).Note().SetLength(value)
 	}
 }
 
@@ -104,9 +121,99 @@ func (l Length) SetAlt(value int) {
 }
 
 func (l Length) SetEditValue(input string) {
-	value, err := strconv.Atoi(input)
+	if values, mode, ok := parseSequence(input, parseLength); ok {
+		for _, n := range l.nodes {
+			n.(music.Audible).Note().Length.SetSequence(values, mode)
+		}
+		return
+	}
+	value, err := parseLength(input)
 	if err != nil {
 		return
 	}
 	l.Set(value)
+	for _, n := range l.nodes {
+		n.(music.Audible).Note().Length.Sequence().Clear()
+	}
+}
+
+func (l Length) setDistribution(distribution common.Distribution) {
+	for _, n := range l.nodes {
+		n.(music.Audible).Note().Length.SetDistribution(distribution)
+	}
+}
+
+func (l Length) toggleLegato() {
+	legato := !l.nodes[0].(music.Audible).Note().Legato
+	for _, n := range l.nodes {
+		n.(music.Audible).Note().Legato = legato
+	}
+}
+
+// noteLengths returns the names of the note lengths shorter than a bar, in
+// pulses: straight, triplet and dotted notes from 1/32 to 1/2.
+func noteLengths() map[int]string {
+	names := map[int]string{}
+	for division := 2; division <= 32; division *= 2 {
+		length := music.BarLength / division
+		names[length] = fmt.Sprintf("1/%d", division)
+		if length*2%3 == 0 {
+			names[length*2/3] = fmt.Sprintf("1/%dt", division)
+		}
+		if length*3%2 == 0 {
+			names[length*3/2] = fmt.Sprintf("1/%d.", division)
+		}
+	}
+	return names
+}
+
+// musicalLengths returns the sorted lengths reached with up and down.
+func musicalLengths() []int {
+	lengths := []int{music.MinLength, music.InfiniteLength}
+	for length := range noteLengths() {
+		lengths = append(lengths, length)
+	}
+	for _, bars := range lengthBars {
+		lengths = append(lengths, bars*music.BarLength)
+	}
+	slices.Sort(lengths)
+	return slices.Compact(lengths)
+}
+
+// lengthName returns the musical name of a length in pulses, or its number
+// of steps when it has none.
+func lengthName(length int) string {
+	if length >= music.InfiniteLength {
+		return "inf"
+	} else if name, ok := noteLengths()[length]; ok {
+		return name
+	} else if length == music.BarLength {
+		return "1 bar"
+	} else if length%music.BarLength == 0 {
+		return fmt.Sprintf("%d bars", length/music.BarLength)
+	}
+	return fmt.Sprintf("%.1f", float64(length)/float64(common.PulsesPerStep))
+}
+
+// parseLength parses a length from its musical name (ex: "1/8.", "2 bars"),
+// "inf" or a number of pulses.
+func parseLength(input string) (int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "inf" {
+		return music.InfiniteLength, nil
+	}
+	for length, name := range noteLengths() {
+		if name == input {
+			return length, nil
+		}
+	}
+	var bars int
+	if _, err := fmt.Sscanf(input, "%d bar", &bars); err == nil {
+		return bars * music.BarLength, nil
+	}
+	length, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, errors.New("unknown length")
+	}
+	return length, nil
 }
@@ -0,0 +1,77 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+)
+
+type Lifetime struct {
+	nodes []common.Node
+}
+
+func (l Lifetime) Name() string {
+	return "life"
+}
+
+func (l Lifetime) Help() string {
+	if l.Value() == 0 {
+		return ""
+	}
+	return "steps before signals disappear"
+}
+
+func (l Lifetime) Display() string {
+	if l.Value() == 0 {
+		return "∞"
+	}
+	return fmt.Sprintf("%d", l.Value())
+}
+
+func (l Lifetime) Value() int {
+	return l.nodes[0].(common.Signaling).Lifetime()
+}
+
+func (l Lifetime) AltValue() int {
+	return 0
+}
+
+func (l Lifetime) Up() {
+	l.Set(l.Value() + 1)
+}
+
+func (l Lifetime) Down() {
+	l.Set(l.Value() - 1)
+}
+
+func (l Lifetime) Left() {}
+
+func (l Lifetime) Right() {}
+
+func (l Lifetime) AltUp() {}
+
+func (l Lifetime) AltDown() {}
+
+func (l Lifetime) AltLeft() {}
+
+func (l Lifetime) AltRight() {}
+
+func (l Lifetime) Set(value int) {
+	if value < 0 || value > common.MaxLifetime {
+		return
+	}
+	for _, n := range l.nodes {
+		n.(common.Signaling).SetLifetime(value)
+	}
+}
+
+func (l Lifetime) SetAlt(value int) {}
+
+func (l Lifetime) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	l.Set(value)
+}
@@ -0,0 +1,73 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type Mirror struct {
+	nodes []common.Node
+}
+
+func (m Mirror) Name() string {
+	return "mirror"
+}
+
+func (m Mirror) Help() string {
+	return ""
+}
+
+func (m Mirror) Display() string {
+	if m.nodes[0].(*node.Mirror).Backslash {
+		return "\\"
+	}
+	return "/"
+}
+
+func (m Mirror) Value() int {
+	if m.nodes[0].(*node.Mirror).Backslash {
+		return 1
+	}
+	return 0
+}
+
+func (m Mirror) AltValue() int {
+	return 0
+}
+
+func (m Mirror) Up() {
+	m.Set(1)
+}
+
+func (m Mirror) Down() {
+	m.Set(0)
+}
+
+func (m Mirror) Left() {}
+
+func (m Mirror) Right() {}
+
+func (m Mirror) AltUp() {}
+
+func (m Mirror) AltDown() {}
+
+func (m Mirror) AltLeft() {}
+
+func (m Mirror) AltRight() {}
+
+func (m Mirror) Set(value int) {
+	for _, n := range m.nodes {
+		n.(*node.Mirror).Backslash = value != 0
+	}
+}
+
+func (m Mirror) SetAlt(value int) {}
+
+func (m Mirror) SetEditValue(input string) {
+	switch input {
+	case "/":
+		m.Set(0)
+	case "\\":
+		m.Set(1)
+	}
+}
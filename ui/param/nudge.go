@@ -0,0 +1,80 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/music"
+)
+
+type Nudge struct {
+	nodes []common.Node
+}
+
+func (n Nudge) Name() string {
+	return "ndg"
+}
+
+func (n Nudge) Help() string {
+	if nudge := n.Value(); nudge > 0 {
+		return fmt.Sprintf("plays %d pulses late", nudge)
+	} else if nudge < 0 {
+		return fmt.Sprintf("plays %d pulses early", -nudge)
+	}
+	return ""
+}
+
+func (n Nudge) Display() string {
+	if n.Value() == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%+d", n.Value())
+}
+
+func (n Nudge) Value() int {
+	return n.nodes[0].(music.Audible).Note().Nudge
+}
+
+func (n Nudge) AltValue() int {
+	return 0
+}
+
+func (n Nudge) Up() {
+	n.Set(n.Value() + 1)
+}
+
+func (n Nudge) Down() {
+	n.Set(n.Value() - 1)
+}
+
+func (n Nudge) Left() {}
+
+func (n Nudge) Right() {}
+
+func (n Nudge) AltUp() {}
+
+func (n Nudge) AltDown() {}
+
+func (n Nudge) AltLeft() {}
+
+func (n Nudge) AltRight() {}
+
+func (n Nudge) Set(value int) {
+	if value < music.MinNudge || value > music.MaxNudge {
+		return
+	}
+	for _, node := range n.nodes {
+		node.(music.Audible).Note().Nudge = value
+	}
+}
+
+func (n Nudge) SetAlt(value int) {}
+
+func (n Nudge) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	n.Set(value)
+}
@@ -0,0 +1,72 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type Octaves struct {
+	nodes []common.Node
+}
+
+func (o Octaves) Name() string {
+	return "oct"
+}
+
+func (o Octaves) Help() string {
+	return ""
+}
+
+func (o Octaves) Display() string {
+	return fmt.Sprintf("%d", o.Value())
+}
+
+func (o Octaves) Value() int {
+	return o.nodes[0].(*node.ArpEmitter).Octaves.Value()
+}
+
+func (o Octaves) AltValue() int {
+	return 0
+}
+
+func (o Octaves) Up() {
+	o.Set(o.Value() + 1)
+}
+
+func (o Octaves) Down() {
+	o.Set(o.Value() - 1)
+}
+
+func (o Octaves) Left() {}
+
+func (o Octaves) Right() {}
+
+func (o Octaves) AltUp() {}
+
+func (o Octaves) AltDown() {}
+
+func (o Octaves) AltLeft() {}
+
+func (o Octaves) AltRight() {}
+
+func (o Octaves) Set(value int) {
+	if value < 1 || value > node.MaxArpOctaves {
+		return
+	}
+	for _, n := range o.nodes {
+		n.(*node.ArpEmitter).Octaves.Set(value)
+	}
+}
+
+func (o Octaves) SetAlt(value int) {}
+
+func (o Octaves) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	o.Set(value)
+}
@@ -1,6 +1,8 @@
 package param
 
 import (
+	"fmt"
+
 	"signls/core/common"
 	"signls/core/field"
 	"signls/core/music"
@@ -47,14 +49,64 @@ func NewParamsForNodes(grid *field.Grid, nodes []common.Node) [][]Param {
 				},
 			},
 		}
+	} else if isHomogeneousNode[*node.Mirror](nodes) {
+		return [][]Param{
+			{
+				Mirror{nodes: nodes},
+			},
+		}
+	} else if isHomogeneousNode[*node.Rotator](nodes) {
+		return [][]Param{
+			{
+				Turn{nodes: nodes},
+			},
+		}
 	} else if isHomogeneousBehavior[*node.TollEmitter](nodes) {
 		return [][]Param{
 			append(
 				DefaultEmitterParams(grid, nodes),
 				Threshold{nodes: nodes},
 			),
+			DefaultEmitterTimingParams(nodes),
 			DefaultEmitterControlChanges(nodes),
 			DefaultEmitterMetaCommands(nodes),
+			DefaultEmitterInputParams(nodes),
+			DefaultEmitterSignalParams(nodes),
+		}
+	} else if isHomogeneousBehavior[*node.ChordEmitter](nodes) {
+		return [][]Param{
+			DefaultEmitterParams(grid, nodes),
+			{
+				Chord{nodes: nodes},
+				Voicing{nodes: nodes},
+				Inversion{nodes: nodes},
+				Strum{nodes: nodes},
+			},
+			DefaultEmitterTimingParams(nodes),
+			DefaultEmitterControlChanges(nodes),
+			DefaultEmitterMetaCommands(nodes),
+			DefaultEmitterInputParams(nodes),
+			DefaultEmitterSignalParams(nodes),
+		}
+	} else if isHomogeneousNode[*node.ArpEmitter](nodes) {
+		return [][]Param{
+			append(
+				DefaultEmitterParams(grid, nodes),
+				ArpPattern{nodes: nodes},
+				Octaves{nodes: nodes},
+				Gate{nodes: nodes},
+				EmitEach{nodes: nodes},
+			),
+			{
+				Chord{nodes: nodes},
+				Voicing{nodes: nodes},
+				Inversion{nodes: nodes},
+			},
+			DefaultEmitterTimingParams(nodes),
+			DefaultEmitterControlChanges(nodes),
+			DefaultEmitterMetaCommands(nodes),
+			DefaultEmitterInputParams(nodes),
+			DefaultEmitterSignalParams(nodes),
 		}
 	} else if isHomogeneousNode[*node.EuclidEmitter](nodes) {
 		return [][]Param{
@@ -64,8 +116,11 @@ func NewParamsForNodes(grid *field.Grid, nodes []common.Node) [][]Param {
 				Triggers{nodes: nodes},
 				Offset{nodes: nodes},
 			),
+			DefaultEmitterTimingParams(nodes),
 			DefaultEmitterControlChanges(nodes),
 			DefaultEmitterMetaCommands(nodes),
+			DefaultEmitterInputParams(nodes),
+			DefaultEmitterSignalParams(nodes),
 		}
 	} else if isHomogeneousBehavior[common.Repeatable](nodes) {
 		return [][]Param{
@@ -73,17 +128,30 @@ func NewParamsForNodes(grid *field.Grid, nodes []common.Node) [][]Param {
 				DefaultEmitterParams(grid, nodes),
 				Repeat{nodes: nodes},
 			),
+			DefaultEmitterTimingParams(nodes),
 			DefaultEmitterControlChanges(nodes),
 			DefaultEmitterMetaCommands(nodes),
+			DefaultEmitterInputParams(nodes),
+			DefaultEmitterSignalParams(nodes),
 		}
 	}
 
 	emitters := filterNodes[music.Audible](nodes)
+	if len(emitters) == 0 {
+		return [][]Param{
+			{
+				NewDirection(nodes),
+			},
+		}
+	}
 
 	return [][]Param{
 		DefaultEmitterParams(grid, emitters),
+		DefaultEmitterTimingParams(emitters),
 		DefaultEmitterControlChanges(emitters),
 		DefaultEmitterMetaCommands(emitters),
+		DefaultEmitterInputParams(emitters),
+		DefaultEmitterSignalParams(emitters),
 	}
 }
 
@@ -103,11 +171,19 @@ func DefaultEmitterParams(grid *field.Grid, nodes []common.Node) []Param {
 		Velocity{nodes: nodes},
 		Length{nodes: nodes},
 		Probability{nodes: nodes},
+		Condition{nodes: nodes},
 		Channel{nodes: nodes},
 		Device{nodes: nodes},
 	}
 }
 
+func DefaultEmitterTimingParams(nodes []common.Node) []Param {
+	return []Param{
+		Nudge{nodes: nodes},
+		Ratchet{nodes: nodes},
+	}
+}
+
 func DefaultEmitterControlChanges(nodes []common.Node) []Param {
 	params := make([]Param, defaultControlParamsNumber)
 	for i := range params {
@@ -125,6 +201,23 @@ func DefaultEmitterMetaCommands(nodes []common.Node) []Param {
 	}
 }
 
+func DefaultEmitterInputParams(nodes []common.Node) []Param {
+	return []Param{
+		InputTrigger{nodes: nodes},
+		Fill{nodes: nodes},
+	}
+}
+
+func DefaultEmitterSignalParams(nodes []common.Node) []Param {
+	return []Param{
+		Rate{nodes: nodes},
+		Speed{nodes: nodes},
+		Lifetime{nodes: nodes},
+		Pitch{nodes: nodes},
+		VelocityScale{nodes: nodes},
+	}
+}
+
 func NewParamsForGrid(grid *field.Grid) []Param {
 	return []Param{
 		Root{grid: grid},
@@ -135,10 +228,23 @@ func NewParamsForGrid(grid *field.Grid) []Param {
 func NewParamsForMidi(grid *field.Grid) [][]Param {
 	return [][]Param{
 		{
+			Tempo{grid: grid},
+			Swing{grid: grid},
 			ClockSend{grid: grid},
 			TransportSend{grid: grid},
 			DefaultDevice{grid: grid},
 		},
+		{
+			InputDevice{grid: grid},
+			ClockReceive{grid: grid},
+			TransposeInput{grid: grid},
+			FillInput{grid: grid},
+		},
+		{
+			Seed{grid: grid},
+			SeedReset{grid: grid},
+			Edge{grid: grid},
+		},
 	}
 }
 
@@ -183,3 +289,16 @@ func isHomogeneousBehavior[T any](nodes []common.Node) bool {
 	}
 	return true
 }
+
+// receivedHelp completes the help of a payload param with the origin of the
+// last signal that hit the selected node.
+func receivedHelp(help string, nodes []common.Node) string {
+	if len(nodes) != 1 {
+		return help
+	}
+	r, ok := nodes[0].(common.Receiver)
+	if !ok || r.Received().Origin == nil {
+		return help
+	}
+	return fmt.Sprintf("%s, last hit %s", help, r.Received().Describe())
+}
@@ -0,0 +1,77 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+)
+
+type Pitch struct {
+	nodes []common.Node
+}
+
+func (p Pitch) Name() string {
+	return "pitch"
+}
+
+func (p Pitch) Help() string {
+	return receivedHelp("semitones added to the notes hit by signals", p.nodes)
+}
+
+func (p Pitch) Display() string {
+	return fmt.Sprintf("%+d", p.Value())
+}
+
+func (p Pitch) Value() int {
+	return p.nodes[0].(common.Carrier).Payload().Transpose
+}
+
+func (p Pitch) AltValue() int {
+	return 0
+}
+
+func (p Pitch) Up() {
+	p.Set(p.Value() + 1)
+}
+
+func (p Pitch) Down() {
+	p.Set(p.Value() - 1)
+}
+
+func (p Pitch) Left() {
+	p.Set(p.Value() - 12)
+}
+
+func (p Pitch) Right() {
+	p.Set(p.Value() + 12)
+}
+
+func (p Pitch) AltUp() {}
+
+func (p Pitch) AltDown() {}
+
+func (p Pitch) AltLeft() {}
+
+func (p Pitch) AltRight() {}
+
+func (p Pitch) Set(value int) {
+	if value < -common.MaxPayloadTranspose || value > common.MaxPayloadTranspose {
+		return
+	}
+	for _, n := range p.nodes {
+		payload := n.(common.Carrier).Payload()
+		payload.Transpose = value
+		n.(common.Carrier).SetPayload(payload)
+	}
+}
+
+func (p Pitch) SetAlt(value int) {}
+
+func (p Pitch) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	p.Set(value)
+}
@@ -0,0 +1,117 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/music"
+)
+
+const ratchetDecayStep = 10
+
+type Ratchet struct {
+	nodes []common.Node
+}
+
+func (r Ratchet) Name() string {
+	return "rtc"
+}
+
+func (r Ratchet) Help() string {
+	ratchet := r.ratchet()
+	if ratchet.Count <= 1 {
+		return ""
+	}
+	help := fmt.Sprintf("%d notes over the step", ratchet.Count)
+	if ratchet.Rate > 0 {
+		help = fmt.Sprintf("%d notes every %d pulses", ratchet.Count, ratchet.Rate)
+	}
+	if ratchet.Decay > 0 {
+		help = fmt.Sprintf("%s, velocity -%d%% each", help, ratchet.Decay)
+	}
+	return help
+}
+
+func (r Ratchet) Display() string {
+	ratchet := r.ratchet()
+	if ratchet.Count <= 1 || ratchet.Rate == 0 {
+		return fmt.Sprintf("%d", max(ratchet.Count, 1))
+	}
+	return fmt.Sprintf("%d/%d", ratchet.Count, ratchet.Rate)
+}
+
+func (r Ratchet) Value() int {
+	return r.ratchet().Count
+}
+
+func (r Ratchet) AltValue() int {
+	return r.ratchet().Rate
+}
+
+func (r Ratchet) Up() {
+	r.Set(r.Value() + 1)
+}
+
+func (r Ratchet) Down() {
+	r.Set(r.Value() - 1)
+}
+
+func (r Ratchet) Left() {
+	r.SetAlt(r.AltValue() - 1)
+}
+
+func (r Ratchet) Right() {
+	r.SetAlt(r.AltValue() + 1)
+}
+
+func (r Ratchet) AltUp() {
+	r.setDecay(r.ratchet().Decay + ratchetDecayStep)
+}
+
+func (r Ratchet) AltDown() {
+	r.setDecay(r.ratchet().Decay - ratchetDecayStep)
+}
+
+func (r Ratchet) AltLeft() {}
+
+func (r Ratchet) AltRight() {}
+
+func (r Ratchet) Set(value int) {
+	if value < 1 || value > music.MaxRatchets {
+		return
+	}
+	for _, n := range r.nodes {
+		n.(music.Audible).Note().Ratchet.Count = value
+	}
+}
+
+func (r Ratchet) SetAlt(value int) {
+	if value < 0 || value > music.MaxRatchetRate {
+		return
+	}
+	for _, n := range r.nodes {
+		n.(music.Audible).Note().Ratchet.Rate = value
+	}
+}
+
+func (r Ratchet) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	r.Set(value)
+}
+
+func (r Ratchet) setDecay(decay int) {
+	if decay < 0 || decay > music.MaxRatchetDecay {
+		return
+	}
+	for _, n := range r.nodes {
+		n.(music.Audible).Note().Ratchet.Decay = decay
+	}
+}
+
+func (r Ratchet) ratchet() music.Ratchet {
+	return r.nodes[0].(music.Audible).Note().Ratchet
+}
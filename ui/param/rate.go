@@ -0,0 +1,73 @@
+package param
+
+import (
+	"signls/core/common"
+)
+
+type Rate struct {
+	nodes []common.Node
+}
+
+func (r Rate) Name() string {
+	return "rate"
+}
+
+func (r Rate) Help() string {
+	return "↑↓ faster/slower"
+}
+
+func (r Rate) Display() string {
+	return r.rate().Symbol()
+}
+
+func (r Rate) rate() common.Rate {
+	return r.nodes[0].(common.Rated).Rate()
+}
+
+func (r Rate) Value() int {
+	return int(r.rate())
+}
+
+func (r Rate) AltValue() int {
+	return 0
+}
+
+func (r Rate) Up() {
+	r.Set(r.Value() + 1)
+}
+
+func (r Rate) Down() {
+	r.Set(r.Value() - 1)
+}
+
+func (r Rate) Left() {}
+
+func (r Rate) Right() {}
+
+func (r Rate) AltUp() {}
+
+func (r Rate) AltDown() {}
+
+func (r Rate) AltLeft() {}
+
+func (r Rate) AltRight() {}
+
+func (r Rate) Set(value int) {
+	if value < int(common.MinRate) || value > int(common.MaxRate) {
+		return
+	}
+	for _, n := range r.nodes {
+		n.(common.Rated).SetRate(common.Rate(value))
+	}
+}
+
+func (r Rate) SetAlt(value int) {}
+
+func (r Rate) SetEditValue(input string) {
+	for rate := common.MinRate; rate <= common.MaxRate; rate++ {
+		if rate.Symbol() == input {
+			r.Set(int(rate))
+			return
+		}
+	}
+}
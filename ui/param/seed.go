@@ -0,0 +1,70 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/field"
+)
+
+type Seed struct {
+	grid *field.Grid
+}
+
+func (s Seed) Name() string {
+	return "seed"
+}
+
+func (s Seed) Help() string {
+	return "←→ reseed"
+}
+
+func (s Seed) Display() string {
+	return fmt.Sprintf("%d", s.grid.Seed())
+}
+
+func (s Seed) Value() int {
+	return int(s.grid.Seed())
+}
+
+func (s Seed) AltValue() int {
+	return 0
+}
+
+func (s Seed) Up() {
+	s.Set(s.Value() + 1)
+}
+
+func (s Seed) Down() {
+	s.Set(s.Value() - 1)
+}
+
+func (s Seed) Left() {
+	s.grid.Reseed()
+}
+
+func (s Seed) Right() {
+	s.grid.Reseed()
+}
+
+func (s Seed) AltUp() {}
+
+func (s Seed) AltDown() {}
+
+func (s Seed) AltLeft() {}
+
+func (s Seed) AltRight() {}
+
+func (s Seed) Set(value int) {
+	s.grid.SetSeed(int64(value))
+}
+
+func (s Seed) SetAlt(value int) {}
+
+func (s Seed) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	s.Set(value)
+}
@@ -0,0 +1,61 @@
+package param
+
+import (
+	"signls/core/field"
+)
+
+type SeedReset struct {
+	grid *field.Grid
+}
+
+func (s SeedReset) Name() string {
+	return "replay"
+}
+
+func (s SeedReset) Help() string {
+	if s.grid.ResetSeed {
+		return "same random sequence on each play"
+	}
+	return ""
+}
+
+func (s SeedReset) Display() string {
+	if s.grid.ResetSeed {
+		return "on"
+	}
+	return "off"
+}
+
+func (s SeedReset) Value() int {
+	return 0
+}
+
+func (s SeedReset) AltValue() int {
+	return 0
+}
+
+func (s SeedReset) Up() {
+	s.grid.ResetSeed = true
+}
+
+func (s SeedReset) Down() {
+	s.grid.ResetSeed = false
+}
+
+func (s SeedReset) Left() {}
+
+func (s SeedReset) Right() {}
+
+func (s SeedReset) AltUp() {}
+
+func (s SeedReset) AltDown() {}
+
+func (s SeedReset) AltLeft() {}
+
+func (s SeedReset) AltRight() {}
+
+func (s SeedReset) Set(value int) {}
+
+func (s SeedReset) SetAlt(value int) {}
+
+func (s SeedReset) SetEditValue(input string) {}
@@ -0,0 +1,73 @@
+package param
+
+import (
+	"strings"
+
+	"signls/core/common"
+)
+
+// sequenceModes lists the sequence mode symbols, longest first so that
+// prefixes are matched properly.
+var sequenceModes = []string{"<>", "<", ">", "?"}
+
+// parseSequence parses a list of comma separated values, optionally
+// prefixed by the symbol of a sequence mode (ex: "<>100,60,80"). It returns
+// false when the input is a single value or cannot be parsed.
+func parseSequence(input string, parse func(string) (int, error)) ([]int, common.SequenceMode, bool) {
+	mode := common.FORWARD
+	prefixed := false
+	for _, symbol := range sequenceModes {
+		if strings.HasPrefix(input, symbol) {
+			mode, _ = common.ParseSequenceMode(symbol)
+			input = strings.TrimPrefix(input, symbol)
+			prefixed = true
+			break
+		}
+	}
+	if !prefixed && !strings.Contains(input, ",") {
+		return nil, mode, false
+	}
+
+	values := []int{}
+	for _, field := range strings.Split(input, ",") {
+		value, err := parse(strings.TrimSpace(field))
+		if err != nil {
+			return nil, mode, false
+		}
+		values = append(values, value)
+	}
+	return values, mode, true
+}
+
+// displaySequence returns the values of a sequence prefixed by the symbol
+// of its mode.
+func displaySequence(mode common.SequenceMode, values []string) string {
+	return mode.Symbol() + strings.Join(values, ",")
+}
+
+// valueHelp returns the help of a parameter with a sequence or a random
+// amount.
+func valueHelp[T common.Number](sequence *common.Sequence[T], amount int, distribution common.Distribution) string {
+	help := []string{}
+	if sequence.Len() > 0 {
+		help = append(help, sequence.Mode().Name()+" sequence")
+	}
+	if amount != 0 {
+		help = append(help, distribution.Name()+" random")
+	}
+	return strings.Join(help, ", ")
+}
+
+// sequenceOf converts parsed values to the type of a control value. Values
+// out of its range are ignored before being converted, so that they never
+// wrap around into the range.
+func sequenceOf[T common.Number](values []int, min, max T) []T {
+	sequence := make([]T, 0, len(values))
+	for _, v := range values {
+		if v < int(min) || v > int(max) {
+			continue
+		}
+		sequence = append(sequence, T(v))
+	}
+	return sequence
+}
@@ -0,0 +1,73 @@
+package param
+
+import (
+	"signls/core/common"
+)
+
+type Speed struct {
+	nodes []common.Node
+}
+
+func (s Speed) Name() string {
+	return "speed"
+}
+
+func (s Speed) Help() string {
+	return "cells per step"
+}
+
+func (s Speed) Display() string {
+	return s.speed().Symbol()
+}
+
+func (s Speed) speed() common.Speed {
+	return s.nodes[0].(common.Signaling).Speed()
+}
+
+func (s Speed) Value() int {
+	return int(s.speed())
+}
+
+func (s Speed) AltValue() int {
+	return 0
+}
+
+func (s Speed) Up() {
+	s.Set(s.Value() + 1)
+}
+
+func (s Speed) Down() {
+	s.Set(s.Value() - 1)
+}
+
+func (s Speed) Left() {}
+
+func (s Speed) Right() {}
+
+func (s Speed) AltUp() {}
+
+func (s Speed) AltDown() {}
+
+func (s Speed) AltLeft() {}
+
+func (s Speed) AltRight() {}
+
+func (s Speed) Set(value int) {
+	if value < int(common.MinSpeed) || value > int(common.MaxSpeed) {
+		return
+	}
+	for _, n := range s.nodes {
+		n.(common.Signaling).SetSpeed(common.Speed(value))
+	}
+}
+
+func (s Speed) SetAlt(value int) {}
+
+func (s Speed) SetEditValue(input string) {
+	for speed := common.MinSpeed; speed <= common.MaxSpeed; speed++ {
+		if speed.Symbol() == input {
+			s.Set(int(speed))
+			return
+		}
+	}
+}
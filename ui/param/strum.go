@@ -0,0 +1,80 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+	"signls/core/music"
+	"signls/core/node"
+)
+
+type Strum struct {
+	nodes []common.Node
+}
+
+func (s Strum) Name() string {
+	return "strum"
+}
+
+func (s Strum) Help() string {
+	if s.Value() == 0 {
+		return ""
+	}
+	return "pulses between chord keys"
+}
+
+func (s Strum) Display() string {
+	return fmt.Sprintf("%d", s.Value())
+}
+
+func (s Strum) Value() int {
+	return s.nodes[0].(*node.Emitter).Behavior().(*node.ChordEmitter).Chord().Strum.Value()
+}
+
+func (s Strum) AltValue() int {
+	return 0
+}
+
+func (s Strum) Up() {
+	s.Set(s.Value() + 1)
+}
+
+func (s Strum) Down() {
+	s.Set(s.Value() - 1)
+}
+
+func (s Strum) Left() {
+	s.Set(s.Value() - common.PulsesPerStep)
+}
+
+func (s Strum) Right() {
+	s.Set(s.Value() + common.PulsesPerStep)
+}
+
+func (s Strum) AltUp() {}
+
+func (s Strum) AltDown() {}
+
+func (s Strum) AltLeft() {}
+
+func (s Strum) AltRight() {}
+
+func (s Strum) Set(value int) {
+	if value < 0 || value > music.MaxStrum {
+		return
+	}
+	for _, n := range s.nodes {
+		n.(*node.Emitter).Behavior().(*node.ChordEmitter).Chord().Strum.Set(value)
+	}
+}
+
+func (s Strum) SetAlt(value int) {}
+
+func (s Strum) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	s.Set(value)
+}
@@ -0,0 +1,69 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/field"
+)
+
+type Swing struct {
+	grid *field.Grid
+}
+
+func (s Swing) Name() string {
+	return "swing"
+}
+
+func (s Swing) Help() string {
+	if s.grid.ExternalClock() {
+		return "no swing when following external clock"
+	}
+	return ""
+}
+
+func (s Swing) Display() string {
+	return fmt.Sprintf("%d%%", s.grid.Swing())
+}
+
+func (s Swing) Value() int {
+	return s.grid.Swing()
+}
+
+func (s Swing) AltValue() int {
+	return 0
+}
+
+func (s Swing) Up() {
+	s.Set(s.Value() + 1)
+}
+
+func (s Swing) Down() {
+	s.Set(s.Value() - 1)
+}
+
+func (s Swing) Left() {}
+
+func (s Swing) Right() {}
+
+func (s Swing) AltUp() {}
+
+func (s Swing) AltDown() {}
+
+func (s Swing) AltLeft() {}
+
+func (s Swing) AltRight() {}
+
+func (s Swing) Set(value int) {
+	s.grid.SetSwing(value)
+}
+
+func (s Swing) SetAlt(value int) {}
+
+func (s Swing) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	s.Set(value)
+}
@@ -0,0 +1,81 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"signls/core/field"
+)
+
+type Tempo struct {
+	grid *field.Grid
+}
+
+func (t Tempo) Name() string {
+	return "tempo"
+}
+
+func (t Tempo) Help() string {
+	stats := t.grid.ClockStats()
+	if t.grid.ExternalClock() && stats.Dropped > 0 {
+		return fmt.Sprintf("following external clock, %d pulses dropped", stats.Dropped)
+	} else if t.grid.ExternalClock() {
+		return "following external clock"
+	}
+	if stats.Pulses == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"jitter avg %.2fms max %.2fms, %d late",
+		float64(stats.Mean)/float64(time.Millisecond),
+		float64(stats.Max)/float64(time.Millisecond),
+		stats.Late,
+	)
+}
+
+func (t Tempo) Display() string {
+	return fmt.Sprintf("%.f", t.grid.Tempo())
+}
+
+func (t Tempo) Value() int {
+	return int(t.grid.Tempo())
+}
+
+func (t Tempo) AltValue() int {
+	return 0
+}
+
+func (t Tempo) Up() {
+	t.Set(t.Value() + 1)
+}
+
+func (t Tempo) Down() {
+	t.Set(t.Value() - 1)
+}
+
+func (t Tempo) Left() {}
+
+func (t Tempo) Right() {}
+
+func (t Tempo) AltUp() {}
+
+func (t Tempo) AltDown() {}
+
+func (t Tempo) AltLeft() {}
+
+func (t Tempo) AltRight() {}
+
+func (t Tempo) Set(value int) {
+	t.grid.SetTempo(float64(value))
+}
+
+func (t Tempo) SetAlt(value int) {}
+
+func (t Tempo) SetEditValue(input string) {
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return
+	}
+	t.grid.SetTempo(value)
+}
@@ -0,0 +1,81 @@
+package param
+
+import (
+	"fmt"
+
+	"signls/core/field"
+	"signls/ui/util"
+)
+
+const (
+	maxTransposeChannel = 16
+)
+
+type TransposeInput struct {
+	grid *field.Grid
+}
+
+func (t TransposeInput) Name() string {
+	return "trsp"
+}
+
+func (t TransposeInput) Help() string {
+	if t.grid.TransposeChannel == 0 {
+		return ""
+	} else if t.grid.TransposeScale {
+		return fmt.Sprintf("input channel %d sets root, chords set scale", t.grid.TransposeChannel)
+	}
+	return fmt.Sprintf("input channel %d sets root", t.grid.TransposeChannel)
+}
+
+func (t TransposeInput) Display() string {
+	if t.grid.TransposeChannel == 0 {
+		return "⨯"
+	} else if t.grid.TransposeScale {
+		return util.Normalize(fmt.Sprintf("%d\u0332", t.grid.TransposeChannel))
+	}
+	return fmt.Sprintf("%d", t.grid.TransposeChannel)
+}
+
+func (t TransposeInput) Value() int {
+	return int(t.grid.TransposeChannel)
+}
+
+func (t TransposeInput) AltValue() int {
+	return 0
+}
+
+func (t TransposeInput) Up() {
+	t.Set(t.Value() + 1)
+}
+
+func (t TransposeInput) Down() {
+	t.Set(t.Value() - 1)
+}
+
+func (t TransposeInput) Left() {}
+
+func (t TransposeInput) Right() {}
+
+func (t TransposeInput) AltUp() {}
+
+func (t TransposeInput) AltDown() {}
+
+func (t TransposeInput) AltLeft() {
+	t.grid.TransposeScale = !t.grid.TransposeScale
+}
+
+func (t TransposeInput) AltRight() {
+	t.grid.TransposeScale = !t.grid.TransposeScale
+}
+
+func (t TransposeInput) Set(value int) {
+	if value < 0 || value > maxTransposeChannel {
+		return
+	}
+	t.grid.TransposeChannel = uint8(value)
+}
+
+func (t TransposeInput) SetAlt(value int) {}
+
+func (t TransposeInput) SetEditValue(input string) {}
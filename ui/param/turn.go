@@ -0,0 +1,73 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/node"
+)
+
+type Turn struct {
+	nodes []common.Node
+}
+
+func (t Turn) Name() string {
+	return "turn"
+}
+
+func (t Turn) Help() string {
+	return ""
+}
+
+func (t Turn) Display() string {
+	if t.nodes[0].(*node.Rotator).Clockwise {
+		return "right"
+	}
+	return "left"
+}
+
+func (t Turn) Value() int {
+	if t.nodes[0].(*node.Rotator).Clockwise {
+		return 1
+	}
+	return 0
+}
+
+func (t Turn) AltValue() int {
+	return 0
+}
+
+func (t Turn) Up() {
+	t.Set(1)
+}
+
+func (t Turn) Down() {
+	t.Set(0)
+}
+
+func (t Turn) Left() {}
+
+func (t Turn) Right() {}
+
+func (t Turn) AltUp() {}
+
+func (t Turn) AltDown() {}
+
+func (t Turn) AltLeft() {}
+
+func (t Turn) AltRight() {}
+
+func (t Turn) Set(value int) {
+	for _, n := range t.nodes {
+		n.(*node.Rotator).Clockwise = value != 0
+	}
+}
+
+func (t Turn) SetAlt(value int) {}
+
+func (t Turn) SetEditValue(input string) {
+	switch input {
+	case "left":
+		t.Set(0)
+	case "right":
+		t.Set(1)
+	}
+}
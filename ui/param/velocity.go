@@ -18,10 +18,18 @@ func (v Velocity) Name() string {
 }
 
 func (v Velocity) Help() string {
-	return ""
+	value := v.nodes[0].(music.Audible).Note().Velocity
+	return valueHelp(value.Sequence(), value.RandomAmount(), value.Distribution())
 }
 
 func (v Velocity) Display() string {
+	if sequence := v.nodes[0].(music.Audible).Note().Velocity.Sequence(); sequence.Len() > 0 {
+		values := make([]string, sequence.Len())
+		for i, value := range sequence.Values() {
+			values[i] = fmt.Sprintf("%d", value)
+		}
+		return displaySequence(sequence.Mode(), values)
+	}
 	if v.nodes[0].(music.Audible).Note().Velocity.RandomAmount() != 0 {
 		return util.Normalize(
 			fmt.Sprintf(
@@ -58,9 +66,13 @@ func (v Velocity) Right() {
 	v.SetAlt(v.nodes[0].(music.Audible).Note().Velocity.RandomAmount() + 1)
 }
 
-func (v Velocity) AltUp() {}
+func (v Velocity) AltUp() {
+	v.setDistribution(v.nodes[0].(music.Audible).Note().Velocity.Distribution() + 1)
+}
 
-func (v Velocity) AltDown() {}
+func (v Velocity) AltDown() {
+	v.setDistribution(v.nodes[0].(music.Audible).Note().Velocity.Distribution() - 1)
+}
 
 func (v Velocity) AltLeft() {}
 
@@ -79,9 +91,25 @@ func (v Velocity) SetAlt(value int) {
 }
 
 func (v Velocity) SetEditValue(input string) {
+	if values, mode, ok := parseSequence(input, strconv.Atoi); ok {
+		for _, n := range v.nodes {
+			velocity := n.(music.Audible).Note().Velocity
+			velocity.SetSequence(sequenceOf(values, velocity.Min(), velocity.Max()), mode)
+		}
+		return
+	}
 	value, err := strconv.Atoi(input)
 	if err != nil {
 		return
 	}
 	v.Set(value)
+	for _, n := range v.nodes {
+		n.(music.Audible).Note().Velocity.Sequence().Clear()
+	}
+}
+
+func (v Velocity) setDistribution(distribution common.Distribution) {
+	for _, n := range v.nodes {
+		n.(music.Audible).Note().Velocity.SetDistribution(distribution)
+	}
 }
@@ -0,0 +1,77 @@
+package param
+
+import (
+	"fmt"
+	"strconv"
+
+	"signls/core/common"
+)
+
+type VelocityScale struct {
+	nodes []common.Node
+}
+
+func (v VelocityScale) Name() string {
+	return "vel%"
+}
+
+func (v VelocityScale) Help() string {
+	return receivedHelp("velocity change of the notes hit by signals", v.nodes)
+}
+
+func (v VelocityScale) Display() string {
+	return fmt.Sprintf("%+d%%", v.Value())
+}
+
+func (v VelocityScale) Value() int {
+	return v.nodes[0].(common.Carrier).Payload().Velocity
+}
+
+func (v VelocityScale) AltValue() int {
+	return 0
+}
+
+func (v VelocityScale) Up() {
+	v.Set(v.Value() + 1)
+}
+
+func (v VelocityScale) Down() {
+	v.Set(v.Value() - 1)
+}
+
+func (v VelocityScale) Left() {
+	v.Set(v.Value() - 10)
+}
+
+func (v VelocityScale) Right() {
+	v.Set(v.Value() + 10)
+}
+
+func (v VelocityScale) AltUp() {}
+
+func (v VelocityScale) AltDown() {}
+
+func (v VelocityScale) AltLeft() {}
+
+func (v VelocityScale) AltRight() {}
+
+func (v VelocityScale) Set(value int) {
+	if value < -common.MaxPayloadVelocity || value > common.MaxPayloadVelocity {
+		return
+	}
+	for _, n := range v.nodes {
+		payload := n.(common.Carrier).Payload()
+		payload.Velocity = value
+		n.(common.Carrier).SetPayload(payload)
+	}
+}
+
+func (v VelocityScale) SetAlt(value int) {}
+
+func (v VelocityScale) SetEditValue(input string) {
+	value, err := strconv.Atoi(input)
+	if err != nil {
+		return
+	}
+	v.Set(value)
+}
@@ -0,0 +1,65 @@
+package param
+
+import (
+	"signls/core/common"
+	"signls/core/theory"
+)
+
+type Voicing struct {
+	nodes []common.Node
+}
+
+func (v Voicing) Name() string {
+	return "voice"
+}
+
+func (v Voicing) Help() string {
+	return ""
+}
+
+func (v Voicing) Display() string {
+	return theory.Voicing(v.Value()).Name()
+}
+
+func (v Voicing) Value() int {
+	return nodeChord(v.nodes[0]).Voicing.Value()
+}
+
+func (v Voicing) AltValue() int {
+	return 0
+}
+
+func (v Voicing) Up() {
+	v.Set(v.Value() + 1)
+}
+
+func (v Voicing) Down() {
+	v.Set(v.Value() - 1)
+}
+
+func (v Voicing) Left() {}
+
+func (v Voicing) Right() {}
+
+func (v Voicing) AltUp() {}
+
+func (v Voicing) AltDown() {}
+
+func (v Voicing) AltLeft() {}
+
+func (v Voicing) AltRight() {}
+
+func (v Voicing) Set(value int) {
+	if value < int(theory.CLOSE) {
+		value = int(theory.MaxVoicing)
+	} else if value > int(theory.MaxVoicing) {
+		value = int(theory.CLOSE)
+	}
+	for _, n := range v.nodes {
+		nodeChord(n).Voicing.Set(value)
+	}
+}
+
+func (v Voicing) SetAlt(value int) {}
+
+func (v Voicing) SetEditValue(input string) {}
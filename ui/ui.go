@@ -143,7 +143,9 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch {
 			case key.Matches(msg, m.keymap.EditNode):
 				m.input.Blur()
-				m.activeParam().SetEditValue(m.input.Value())
+				m.grid.Edit(func() {
+					m.activeParam().SetEditValue(m.input.Value())
+				})
 				return m, nil
 			case key.Matches(msg, m.keymap.Cancel, m.keymap.EditInput):
 				m.input.Blur()
@@ -186,7 +188,7 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				dir, 1, m.selectionX, m.selectionY,
 				m.cursorX, m.grid.Width-1, m.cursorY, m.grid.Height-1,
 			)
-			m.params = param.NewParamsForNodes(m.grid, m.selectedEmitters())
+			m.params = m.selectedParams()
 			m.viewport.Update(m.cursorX, m.cursorY, m.grid.Width, m.grid.Height)
 			return m, nil
 		case key.Matches(msg, m.keymap.SelectionUp, m.keymap.SelectionRight, m.keymap.SelectionDown, m.keymap.SelectionLeft):
@@ -199,19 +201,32 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				dir, 1, m.selectionX, m.selectionY,
 				m.cursorX, m.grid.Width-1, m.cursorY, m.grid.Height-1,
 			)
-			m.params = param.NewParamsForNodes(m.grid, m.selectedEmitters())
+			m.params = m.selectedParams()
 			return m, nil
 		case key.Matches(msg, m.keymap.EditUp, m.keymap.EditRight, m.keymap.EditDown, m.keymap.EditLeft):
 			dir := m.keymap.Direction(msg)
 			if m.mode == MOVE {
-				param.NewDirection(m.selectedEmitters()).SetFromKeyString(dir)
+				m.grid.Edit(func() {
+					param.NewDirection(m.selectedEmitters()).SetFromKeyString(dir)
+				})
 				return m, save(m)
 			}
 			m.handleParamEdit(dir)
 			return m, save(m)
-		case key.Matches(msg, m.keymap.AddBang, m.keymap.AddSpread, m.keymap.AddCycle, m.keymap.AddDice, m.keymap.AddToll, m.keymap.AddEuclid, m.keymap.AddZone, m.keymap.AddPass, m.keymap.AddHole):
-			m.grid.AddNodeFromSymbol(m.keymap.EmitterSymbol(msg), m.cursorX, m.cursorY)
-			newParams := param.NewParamsForNodes(m.grid, m.selectedEmitters())
+		case key.Matches(msg, m.keymap.EditUpRight, m.keymap.EditDownRight, m.keymap.EditDownLeft, m.keymap.EditUpLeft):
+			if m.mode != MOVE {
+				return m, nil
+			}
+			dir := m.keymap.Direction(msg)
+			m.grid.Edit(func() {
+				param.NewDirection(m.selectedEmitters()).SetFromKeyString(dir)
+			})
+			return m, save(m)
+		case key.Matches(msg, m.keymap.AddBang, m.keymap.AddSpread, m.keymap.AddCycle, m.keymap.AddDice, m.keymap.AddToll, m.keymap.AddEuclid, m.keymap.AddZone, m.keymap.AddPass, m.keymap.AddHole, m.keymap.AddChord, m.keymap.AddArp, m.keymap.AddMirror, m.keymap.AddRotator, m.keymap.AddValve):
+			m.grid.Edit(func() {
+				m.grid.AddNodeFromSymbol(m.keymap.EmitterSymbol(msg), m.cursorX, m.cursorY)
+			})
+			newParams := m.selectedParams()
 			if len(newParams) < m.paramPage+1 {
 				m.paramPage = 0
 			}
@@ -221,10 +236,14 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.params = newParams
 			return m, save(m)
 		case key.Matches(msg, m.keymap.MuteNode):
-			m.grid.ToggleNodeMutes(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
+			m.grid.Edit(func() {
+				m.grid.ToggleNodeMutes(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
+			})
 			return m, save(m)
 		case key.Matches(msg, m.keymap.MuteAllNode):
-			m.grid.SetAllNodeMutes(!m.mute)
+			m.grid.Edit(func() {
+				m.grid.SetAllNodeMutes(!m.mute)
+			})
 			m.mute = !m.mute
 			return m, save(m)
 		case key.Matches(msg, m.keymap.RemoveNode):
@@ -233,7 +252,9 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.loadGridFromBank(), tea.WindowSize()
 			}
 			m.mode = MOVE
-			m.grid.RemoveNodes(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
+			m.grid.Edit(func() {
+				m.grid.RemoveNodes(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
+			})
 			return m, save(m)
 		case key.Matches(msg, m.keymap.EditNode):
 			if m.mode == BANK {
@@ -244,12 +265,16 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = MOVE
 				return m, nil
 			}
-			if len(m.selectedEmitters()) == 0 {
+			var selected int
+			m.grid.Read(func() {
+				selected = len(m.selectedEmitters())
+			})
+			if selected == 0 {
 				return m, nil
 			}
 			m.mode = m.toggleMode(EDIT)
 			if m.mode == EDIT {
-				m.params = param.NewParamsForNodes(m.grid, m.selectedEmitters())
+				m.params = m.selectedParams()
 			}
 			if len(m.params) < m.paramPage+1 {
 				m.paramPage = 0
@@ -258,15 +283,20 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.param = 0
 			}
 			return m, nil
+		case key.Matches(msg, m.keymap.Fill):
+			m.grid.ToggleFill()
+			return m, nil
 		case key.Matches(msg, m.keymap.TriggerNode):
-			if !m.grid.Playing {
+			if !m.grid.Playing() {
 				return m, nil
 			}
-			if _, ok := m.selectedNode().(*node.Emitter); !ok {
-				return m, nil
-			}
-			m.selectedNode().(*node.Emitter).Arm()
-			m.selectedNode().(*node.Emitter).Trig(m.grid.Key, m.grid.Scale, common.NONE, m.grid.Pulse())
+			m.grid.Edit(func() {
+				if _, ok := m.selectedNode().(*node.Emitter); !ok {
+					return
+				}
+				m.selectedNode().(*node.Emitter).Arm()
+				m.selectedNode().(*node.Emitter).Trig(m.grid.Key, m.grid.Scale, common.NONE, common.Payload{}, m.grid.Pulse())
+			})
 			return m, nil
 		case key.Matches(msg, m.keymap.Bank):
 			m.selectedGrid = m.bank.Active
@@ -276,25 +306,25 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.mode == EDIT {
 				return m, nil
 			}
-			param.Get("root", m.gridParams).Up()
+			m.grid.Edit(param.Get("root", m.gridParams).Up)
 			return m, save(m)
 		case key.Matches(msg, m.keymap.RootNoteDown):
 			if m.mode == EDIT {
 				return m, nil
 			}
-			param.Get("root", m.gridParams).Down()
+			m.grid.Edit(param.Get("root", m.gridParams).Down)
 			return m, save(m)
 		case key.Matches(msg, m.keymap.ScaleUp):
 			if m.mode == EDIT {
 				return m, nil
 			}
-			param.Get("scale", m.gridParams).Up()
+			m.grid.Edit(param.Get("scale", m.gridParams).Up)
 			return m, save(m)
 		case key.Matches(msg, m.keymap.ScaleDown):
 			if m.mode == EDIT {
 				return m, nil
 			}
-			param.Get("scale", m.gridParams).Down()
+			m.grid.Edit(param.Get("scale", m.gridParams).Down)
 			return m, save(m)
 		case key.Matches(msg, m.keymap.TempoUp):
 			m.grid.SetTempo(m.grid.Tempo() + 1)
@@ -313,7 +343,9 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.bankClipboard = m.bank.Grids[m.selectedGrid]
 				return m, nil
 			}
-			m.grid.CopyOrCut(m.cursorX, m.cursorY, m.selectionX, m.selectionY, false)
+			m.grid.Edit(func() {
+				m.grid.CopyOrCut(m.cursorX, m.cursorY, m.selectionX, m.selectionY, false)
+			})
 			return m, nil
 		case key.Matches(msg, m.keymap.Cut):
 			if m.mode == BANK {
@@ -324,14 +356,18 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, tea.WindowSize()
 			}
-			m.grid.CopyOrCut(m.cursorX, m.cursorY, m.selectionX, m.selectionY, true)
+			m.grid.Edit(func() {
+				m.grid.CopyOrCut(m.cursorX, m.cursorY, m.selectionX, m.selectionY, true)
+			})
 			return m, nil
 		case key.Matches(msg, m.keymap.Paste):
 			if m.mode == BANK {
 				m.bank.Grids[m.selectedGrid] = m.bankClipboard
 			}
-			m.grid.Paste(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
-			m.params = param.NewParamsForNodes(m.grid, m.selectedEmitters())
+			m.grid.Edit(func() {
+				m.grid.Paste(m.cursorX, m.cursorY, m.selectionX, m.selectionY)
+			})
+			m.params = m.selectedParams()
 			return m, save(m)
 		case key.Matches(msg, m.keymap.Cancel):
 			m.mode = MOVE
@@ -342,7 +378,9 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keymap.FitGridToWindow):
 			m.cursorX, m.cursorY = 1, 1
 			m.selectionX, m.selectionY = m.cursorX, m.cursorY
-			m.grid.Resize(m.viewport.Width, m.viewport.Height)
+			m.grid.Edit(func() {
+				m.grid.Resize(m.viewport.Width, m.viewport.Height)
+			})
 			m.viewport.Update(m.cursorX, m.cursorY, m.grid.Width, m.grid.Height)
 			return m, save(m)
 		case key.Matches(msg, m.keymap.Help):
@@ -358,6 +396,14 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m mainModel) View() string {
+	var view string
+	m.grid.Read(func() {
+		view = m.view()
+	})
+	return view
+}
+
+func (m mainModel) view() string {
 	help := lipgloss.NewStyle().
 		MarginLeft(2).
 		Render(m.help.View(m.keymap))
@@ -395,7 +441,12 @@ func (m mainModel) handleParamEdit(dir string) {
 	if len(m.activeParamPage()) < m.param+1 {
 		return
 	}
+	m.grid.Edit(func() {
+		m.editParam(dir)
+	})
+}
 
+func (m mainModel) editParam(dir string) {
 	switch dir {
 	case "up":
 		m.activeParam().Up()
@@ -411,7 +462,7 @@ func (m mainModel) handleParamEdit(dir string) {
 
 	switch p := m.activeParam().(type) {
 	case *param.Key:
-		if m.grid.Playing {
+		if m.grid.Playing() {
 			return
 		}
 		p.Preview()
@@ -423,16 +474,18 @@ func (m mainModel) handleParamAltEdit(dir string) {
 		return
 	}
 
-	switch dir {
-	case "up":
-		m.activeParam().AltUp()
-	case "down":
-		m.activeParam().AltDown()
-	case "left":
-		m.activeParam().AltLeft()
-	case "right":
-		m.activeParam().AltRight()
-	}
+	m.grid.Edit(func() {
+		switch dir {
+		case "up":
+			m.activeParam().AltUp()
+		case "down":
+			m.activeParam().AltDown()
+		case "left":
+			m.activeParam().AltLeft()
+		case "right":
+			m.activeParam().AltRight()
+		}
+	})
 }
 
 func (m mainModel) activeParam() param.Param {
@@ -513,9 +566,9 @@ func (m *mainModel) moveBankGrid(dir string) {
 
 func (m mainModel) loadGridFromBank() mainModel {
 	m.bank.Active = m.selectedGrid
-	isPlaying := m.grid.Playing
+	isPlaying := m.grid.Playing()
 	m.grid.Load(m.bank.Active, m.bank.ActiveGrid())
-	m.grid.Playing = isPlaying
+	m.grid.SetPlaying(isPlaying)
 	m.cursorX = 1
 	m.cursorY = 1
 	m.selectionX = 1
@@ -527,12 +580,16 @@ func (m mainModel) loadGridFromBank() mainModel {
 }
 
 func (m mainModel) handleBankMetaCommand() (mainModel, tea.Cmd) {
-	if m.grid.BankIndex == m.bank.Active {
+	var index int
+	m.grid.Read(func() {
+		index = m.grid.BankIndex
+	})
+	if index == m.bank.Active {
 		return m, tick()
 	}
-	m.bank.Active = m.grid.BankIndex
+	m.bank.Active = index
 	m.grid.Load(m.bank.Active, m.bank.ActiveGrid())
-	m.grid.Playing = true
+	m.grid.SetPlaying(true)
 	m.mode = MOVE
 	m.param = 0
 	m.paramPage = 0
@@ -544,7 +601,9 @@ func (m mainModel) windowResize(width, height int) mainModel {
 	m.viewport.Width = width / 2
 	m.viewport.Height = height - controlsHeight - 1
 	if m.viewport.Width > m.grid.Width || m.viewport.Height > m.grid.Height {
-		m.grid.Resize(m.viewport.Width, m.viewport.Height)
+		m.grid.Edit(func() {
+			m.grid.Resize(m.viewport.Width, m.viewport.Height)
+		})
 	}
 	m.viewport.Update(m.cursorX, m.cursorY, m.grid.Width, m.grid.Height)
 	if m.cursorX > m.grid.Width-1 {